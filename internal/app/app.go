@@ -5,6 +5,7 @@ import (
 	"github.com/movie-app/internal/db"
 	"github.com/movie-app/internal/handler"
 	"github.com/movie-app/internal/router"
+	"github.com/movie-app/internal/scheduler"
 	"github.com/movie-app/internal/usecase"
 	"github.com/movie-app/pkg/logger"
 	"go.uber.org/fx"
@@ -13,8 +14,10 @@ import (
 var Module = fx.Options(
 	config.Module,
 	logger.Module,
+	fx.Provide(NewKeySet),
 	db.Module,
 	usecase.Module,
 	handler.Module,
 	router.Module,
+	scheduler.Module,
 )
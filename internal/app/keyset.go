@@ -0,0 +1,37 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/pkg/jwt"
+)
+
+// NewKeySet loads the PEM keys listed in JWT_KEYS ("kid=path,kid=path").
+// Without any keys it falls back to HS256 with JWT_SECRET.
+func NewKeySet(cfg *config.Config) (*jwt.KeySet, error) {
+	if strings.TrimSpace(cfg.JWTKeys) == "" {
+		return jwt.NewHMACKeySet(cfg.JWTSecret), nil
+	}
+
+	var keys []*jwt.Key
+	for _, entry := range strings.Split(cfg.JWTKeys, ",") {
+		kid, path, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || kid == "" || path == "" {
+			return nil, fmt.Errorf("invalid JWT_KEYS entry %q, expected kid=path", entry)
+		}
+		key, err := jwt.LoadKeyFile(kid, path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	current := cfg.JWTSigningKID
+	if current == "" {
+		current = keys[0].ID
+	}
+
+	return jwt.NewKeySet(current, keys...)
+}
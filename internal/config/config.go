@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"strings"
+	"time"
 
 	"sync"
 
@@ -37,11 +39,47 @@ func load() *Config {
 
 	c.Port = cast.ToString(getOrReturnDefault("PORT", "7777"))
 	c.JWTSecret = cast.ToString(getOrReturnDefault("JWT_SECRET", "2343rfe"))
+	c.JWTKeys = cast.ToString(getOrReturnDefault("JWT_KEYS", ""))
+	c.JWTSigningKID = cast.ToString(getOrReturnDefault("JWT_SIGNING_KID", ""))
+	c.AccessTokenTTL = cast.ToDuration(getOrReturnDefault("ACCESS_TOKEN_TTL", "15m"))
+	c.RefreshTokenTTL = cast.ToDuration(getOrReturnDefault("REFRESH_TOKEN_TTL", "720h"))
 	c.LogLevel = cast.ToString(getOrReturnDefault("LOG_LEVEL", "info"))
 
+	c.TopRatedMinVotes = cast.ToInt(getOrReturnDefault("TOP_RATED_MIN_VOTES", 25))
+	c.RankingRefreshInterval = cast.ToDuration(getOrReturnDefault("RANKING_REFRESH_INTERVAL", "10m"))
+
+	c.ProgressFlushInterval = cast.ToDuration(getOrReturnDefault("PROGRESS_FLUSH_INTERVAL", "5s"))
+	c.ProgressBufferSize = cast.ToInt(getOrReturnDefault("PROGRESS_BUFFER_SIZE", 10000))
+	c.ProgressWriteThrough = cast.ToBool(getOrReturnDefault("PROGRESS_WRITE_THROUGH", false))
+
+	c.AutocompleteTimeout = cast.ToDuration(getOrReturnDefault("AUTOCOMPLETE_TIMEOUT", "300ms"))
+
+	c.BulkUpdateMaxRows = cast.ToInt(getOrReturnDefault("BULK_UPDATE_MAX_ROWS", 500))
+	c.RequireIfMatch = cast.ToBool(getOrReturnDefault("REQUIRE_IF_MATCH", false))
+
+	c.CacheControlDefault = cast.ToString(getOrReturnDefault("CACHE_CONTROL_DEFAULT", "no-cache"))
+	c.CacheControl = parseRoutePolicies(cast.ToString(getOrReturnDefault("CACHE_CONTROL", "")))
+	c.IdempotencyKeyTTL = cast.ToDuration(getOrReturnDefault("IDEMPOTENCY_KEY_TTL", "24h"))
+	c.IdempotencyCleanupInterval = cast.ToDuration(getOrReturnDefault("IDEMPOTENCY_CLEANUP_INTERVAL", "1h"))
+
 	return &c
 }
 
+// parseRoutePolicies reads "route=policy;route=policy", where route is a gin
+// route pattern such as /v1/movies/:id. Policies may contain commas, so
+// entries are separated by semicolons.
+func parseRoutePolicies(s string) map[string]string {
+	policies := map[string]string{}
+	for _, entry := range strings.Split(s, ";") {
+		route, policy, ok := strings.Cut(entry, "=")
+		if !ok {
+			continue
+		}
+		policies[strings.TrimSpace(route)] = strings.TrimSpace(policy)
+	}
+	return policies
+}
+
 func getOrReturnDefault(key string, defaultValue interface{}) interface{} {
 	if os.Getenv(key) == "" {
 		return defaultValue
@@ -58,4 +96,49 @@ type Config struct {
 	JWTSecret  string
 	Port       string
 	LogLevel   string
+
+	// JWTKeys lists PEM key files as "kid=path,kid=path". The key named by
+	// JWTSigningKID (or the first one) signs; all of them verify.
+	JWTKeys         string
+	JWTSigningKID   string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	// TopRatedMinVotes is the default vote threshold (the "m" of the weighted
+	// rating) for GET /v1/movies/top.
+	TopRatedMinVotes       int
+	RankingRefreshInterval time.Duration
+
+	// Playback progress reports are buffered in memory and written every
+	// ProgressFlushInterval, or sooner once ProgressBufferSize movies are
+	// pending. The buffer is per process: reports still in it are lost if
+	// the process dies, and other replicas do not see them until they are
+	// flushed. ProgressWriteThrough skips the buffer and upserts every
+	// report as it arrives, for deployments that cannot accept either.
+	ProgressFlushInterval time.Duration
+	ProgressBufferSize    int
+	ProgressWriteThrough  bool
+
+	// AutocompleteTimeout bounds a type-ahead lookup; a slow suggestion is
+	// worthless once the user has typed the next key.
+	AutocompleteTimeout time.Duration
+
+	// BulkUpdateMaxRows is the most rows a single PUT /v1/movies/field may
+	// change; larger matches are rejected as a whole.
+	BulkUpdateMaxRows int
+	// RequireIfMatch makes PUT, PATCH and DELETE on movies and actors
+	// refuse requests without an If-Match header instead of applying them
+	// unconditionally.
+	RequireIfMatch bool
+
+	// CacheControl maps GET route patterns to their Cache-Control header;
+	// other cacheable reads get CacheControlDefault.
+	CacheControl        map[string]string
+	CacheControlDefault string
+
+	// Responses to POSTs sent with an Idempotency-Key are kept for
+	// IdempotencyKeyTTL; expired keys are purged every
+	// IdempotencyCleanupInterval.
+	IdempotencyKeyTTL          time.Duration
+	IdempotencyCleanupInterval time.Duration
 }
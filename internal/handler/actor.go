@@ -1,38 +1,47 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/movie-app/internal/config"
 	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/internal/rbac"
 	"github.com/movie-app/internal/usecase"
 	"github.com/movie-app/pkg/logger"
 )
 
 type ActorHandler struct {
-	usecase *usecase.UseCase
-	cfg     *config.Config
-	logger  *logger.Logger
+	usecase     *usecase.UseCase
+	cfg         *config.Config
+	logger      *logger.Logger
+	auth        *AuthMiddleware
+	idempotency *IdempotencyMiddleware
 }
 
-func NewActorHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger) *ActorHandler {
+func NewActorHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware, idempotency *IdempotencyMiddleware) *ActorHandler {
 	return &ActorHandler{
-		usecase: usecase,
-		logger:  logger,
-		cfg:     cfg,
+		usecase:     usecase,
+		logger:      logger,
+		cfg:         cfg,
+		auth:        auth,
+		idempotency: idempotency,
 	}
 }
 
 func (h *ActorHandler) RegisterRoutes(r *gin.Engine) {
 	actorHandler := r.Group("/v1/actors")
 	{
-		actorHandler.POST("", h.Create)
-		actorHandler.GET("/:id", h.GetByID)
-		actorHandler.PUT("/:id", h.Update)
-		actorHandler.GET("", h.GetList)
-		actorHandler.DELETE("/:id", h.Delete)
+		actorHandler.POST("", h.auth.Authorize(rbac.ActorsWrite), h.idempotency.Handle(), h.Create)
+		actorHandler.GET("/:id", h.auth.Optional(rbac.ActorsRead), h.GetByID)
+		actorHandler.PUT("/:id", h.auth.Authorize(rbac.ActorsWrite), h.Update)
+		actorHandler.PATCH("/:id", h.auth.Authorize(rbac.ActorsWrite), h.Patch)
+		actorHandler.GET("", h.auth.Optional(rbac.ActorsRead), h.GetList)
+		actorHandler.DELETE("/:id", h.auth.Authorize(rbac.ActorsDelete), h.Delete)
 	}
 }
 
@@ -43,9 +52,17 @@ func (h *ActorHandler) RegisterRoutes(r *gin.Engine) {
 // @Accept json
 // @Produce json
 // @Param actor body model.Actor true "Actor data"
+// @Param Idempotency-Key header string false "Makes the request safe to retry; a retry with the same key replays the first response"
 // @Success 201 {object} model.Actor
+// @Header 201 {string} Idempotent-Replayed "Set when the response is a replay"
 // @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse "A request with the same Idempotency-Key is still running"
+// @Failure 422 {object} model.ErrorResponse "Idempotency-Key reused with a different body"
 // @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /v1/actors [post]
 func (h *ActorHandler) Create(c *gin.Context) {
 	var req model.Actor
@@ -55,7 +72,10 @@ func (h *ActorHandler) Create(c *gin.Context) {
 		return
 	}
 
-	res, err := h.usecase.ActorRepo.Create(c.Request.Context(), req)
+	// The id, version and timestamps are assigned by the database.
+	actor := model.Actor{FirstName: req.FirstName, LastName: req.LastName, Role: req.Role}
+
+	res, err := h.usecase.ActorRepo.Create(c.Request.Context(), actor)
 	if err != nil {
 		h.logger.Error("failed to create actor: %v", err)
 		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to create actor", Code: "INTERNAL_ERROR"})
@@ -71,7 +91,11 @@ func (h *ActorHandler) Create(c *gin.Context) {
 // @Tags actors
 // @Produce json
 // @Param id path int true "Actor ID"
+// @Param If-None-Match header string false "ETag from a previous response; answered with 304 if unchanged"
 // @Success 200 {object} model.Actor
+// @Success 304 {string} string "Not modified"
+// @Header 200 {string} ETag "Entity tag of the representation"
+// @Header 200 {string} Cache-Control "Caching policy configured for the route"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
 // @Router /v1/actors/{id} [get]
@@ -91,7 +115,7 @@ func (h *ActorHandler) GetByID(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, actor)
+	writeCacheable(c, h.cfg, actor, actor.Version, actor.UpdatedAt)
 }
 
 // Update godoc
@@ -102,9 +126,18 @@ func (h *ActorHandler) GetByID(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Actor ID"
 // @Param actor body model.Actor true "Actor data"
+// @Param If-Match header string false "ETag from a previous response; the change is refused with 412 if the resource was modified since"
 // @Success 200 {object} model.Actor
+// @Header 200 {string} ETag "Version tag to send back in If-Match"
 // @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 412 {object} model.Actor "The current representation"
+// @Failure 428 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /v1/actors/{id} [put]
 func (h *ActorHandler) Update(c *gin.Context) {
 	idStr := c.Param("id")
@@ -115,6 +148,11 @@ func (h *ActorHandler) Update(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatch(c, h.cfg.RequireIfMatch)
+	if !ok {
+		return
+	}
+
 	var req model.Actor
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("invalid update payload: %v", err)
@@ -122,15 +160,78 @@ func (h *ActorHandler) Update(c *gin.Context) {
 		return
 	}
 	req.ID = id
+	req.Version = version
 
 	updated, err := h.usecase.ActorRepo.Update(c.Request.Context(), req)
 	if err != nil {
-		h.logger.Error("failed to update actor: %v", err)
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to update actor", Code: "INTERNAL_ERROR"})
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Actor not found", Code: "NOT_FOUND"})
+		case errors.Is(err, model.ErrVersionMismatch):
+			h.preconditionFailed(c, id)
+		default:
+			h.logger.Error("failed to update actor: %v", err)
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to update actor", Code: "INTERNAL_ERROR"})
+		}
 		return
 	}
 
-	c.JSON(http.StatusOK, updated)
+	writeTagged(c, http.StatusOK, updated, updated.Version)
+}
+
+// Patch godoc
+// @Summary Partially update an actor
+// @Description Applies a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) to the actor document {first_name, last_name, role}. Fields left out of a merge patch keep their values.
+// @Tags actors
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int true "Actor ID"
+// @Param patch body model.ActorDocument true "Merge patch, or an array of JSON Patch operations"
+// @Param If-Match header string false "ETag from a previous response; the change is refused with 412 if the resource was modified since"
+// @Success 200 {object} model.Actor
+// @Header 200 {string} ETag "Version tag to send back in If-Match"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 415 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 412 {object} model.Actor "The current representation"
+// @Failure 428 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /v1/actors/{id} [patch]
+func (h *ActorHandler) Patch(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid actor ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	version, ok := ifMatch(c, h.cfg.RequireIfMatch)
+	if !ok {
+		return
+	}
+
+	patch, ok := readPatch[model.ActorDocument](c)
+	if !ok {
+		return
+	}
+
+	actor, err := h.usecase.ActorRepo.Patch(c.Request.Context(), id, version, patch)
+	if err != nil {
+		if errors.Is(err, model.ErrVersionMismatch) {
+			h.preconditionFailed(c, id)
+			return
+		}
+		patchFailed(c, h.logger, err, "Actor")
+		return
+	}
+
+	writeTagged(c, http.StatusOK, actor, actor.Version)
 }
 
 // Delete godoc
@@ -138,9 +239,16 @@ func (h *ActorHandler) Update(c *gin.Context) {
 // @Description Deletes an actor by ID
 // @Tags actors
 // @Param id path int true "Actor ID"
+// @Param If-Match header string false "ETag from a previous response; the change is refused with 412 if the resource was modified since"
 // @Success 200 {object} model.SuccessResponse
 // @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 412 {object} model.Actor "The current representation"
+// @Failure 428 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /v1/actors/{id} [delete]
 func (h *ActorHandler) Delete(c *gin.Context) {
 	idStr := c.Param("id")
@@ -151,40 +259,107 @@ func (h *ActorHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.usecase.ActorRepo.Delete(c.Request.Context(), uint(id)); err != nil {
-		h.logger.Error("failed to delete actor: %v", err)
-		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to delete actor", Code: "INTERNAL_ERROR"})
+	version, ok := ifMatch(c, h.cfg.RequireIfMatch)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.ActorRepo.Delete(c.Request.Context(), uint(id), version); err != nil {
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Actor not found", Code: "NOT_FOUND"})
+		case errors.Is(err, model.ErrVersionMismatch):
+			h.preconditionFailed(c, id)
+		default:
+			h.logger.Error("failed to delete actor: %v", err)
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to delete actor", Code: "INTERNAL_ERROR"})
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Actor deleted successfully"})
 }
 
+// preconditionFailed responds 412 with the actor as it is now.
+func (h *ActorHandler) preconditionFailed(c *gin.Context, id int) {
+	actor, err := h.usecase.ActorRepo.GetByID(c.Request.Context(), uint(id))
+	if err != nil {
+		h.logger.Error("failed to fetch actor after version mismatch: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch actor", Code: "INTERNAL_ERROR"})
+		return
+	}
+	preconditionFailed(c, actor, actor.Version)
+}
+
 // GetList godoc
 // @Summary Get a list of actors
-// @Description Retrieves a paginated list of actors
+// @Description Retrieves a paginated list of actors.
+// @Description Filter with field=value or field[op]=value where op is one of eq, ne, gt, gte, lt, lte, search, in, between, is_null (e.g. first_name[search]=tom&role=actor).
+// @Description Fields: id, first_name, last_name, role, created_at, updated_at, movie_id, movie_year.
 // @Tags actors
 // @Produce json
-// @Param page query int false "Page number"
-// @Param limit query int false "Page size"
+// @Param page query int false "Page number (default is 1)"
+// @Param limit query int false "Items per page (default is 10, max 100)"
+// @Param first_name query string false "Filter by first name"
+// @Param last_name query string false "Filter by last name"
+// @Param role query string false "Filter by role (e.g. actor, director)"
+// @Param movie_id query string false "Comma separated movie IDs; actors cast in any of them"
+// @Param year_from query int false "Only actors cast in a movie released in or after this year"
+// @Param year_to query int false "Only actors cast in a movie released in or before this year"
+// @Param sort query string false "Comma separated sort keys, prefix with - for descending (e.g. -created_at)"
+// @Param after query string false "Cursor from next_cursor of the previous response; returns the following page"
+// @Param before query string false "Cursor from prev_cursor of the previous response; returns the preceding page"
+// @Param count query bool false "Set to false to skip counting the total"
+// @Param If-None-Match header string false "ETag from a previous response; answered with 304 if unchanged"
 // @Success 200 {object} model.ActorList
+// @Success 304 {string} string "Not modified"
+// @Header 200 {string} ETag "Entity tag of the representation"
+// @Header 200 {string} Cache-Control "Caching policy configured for the route"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /v1/actors [get]
 func (h *ActorHandler) GetList(c *gin.Context) {
-	var req model.GetListFilter
-	if err := c.ShouldBindQuery(&req); err != nil {
-		h.logger.Error("invalid query params: %v", err)
-		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid query params", Code: "BAD_REQUEST"})
+	req, err := querybuilder.ParseQuery(c.Request.URL.Query(), querybuilder.QueryOptions{
+		DefaultOps: actorDefaultOps,
+		Reserved:   []string{"year_from", "year_to"},
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: err.Error(), Code: "BAD_REQUEST"})
 		return
 	}
+	if f, ok := movieYearFilter(c.Query("year_from"), c.Query("year_to")); ok {
+		req.Filters = append(req.Filters, f)
+	}
 
 	list, err := h.usecase.ActorRepo.GetList(c.Request.Context(), req)
 	if err != nil {
+		if errors.Is(err, model.ErrInvalidFilter) {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: err.Error(), Code: "BAD_REQUEST"})
+			return
+		}
 		h.logger.Error("failed to get actor list: %v", err)
 		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch actor list", Code: "INTERNAL_ERROR"})
 		return
 	}
 
-	c.JSON(http.StatusOK, list)
+	writeCacheable(c, h.cfg, list, 0, time.Time{})
+}
+
+// actorDefaultOps lets ?movie_id=1,2 match actors cast in any of the movies.
+var actorDefaultOps = map[string]querybuilder.Operator{
+	"movie_id": querybuilder.In,
+}
+
+// movieYearFilter turns year_from/year_to into one movie_year filter so both
+// bounds apply to the same movie.
+func movieYearFilter(from, to string) (model.Filter, bool) {
+	switch {
+	case from != "" && to != "":
+		return model.Filter{Column: "movie_year", Type: "between", Value: from + "," + to}, true
+	case from != "":
+		return model.Filter{Column: "movie_year", Type: "gte", Value: from}, true
+	case to != "":
+		return model.Filter{Column: "movie_year", Type: "lte", Value: to}, true
+	}
+	return model.Filter{}, false
 }
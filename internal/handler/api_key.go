@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/rbac"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/logger"
+)
+
+const apiKeyPrefix = "mk_"
+
+type APIKeyHandler struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+	auth    *AuthMiddleware
+}
+
+func NewAPIKeyHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware) *APIKeyHandler {
+	return &APIKeyHandler{
+		usecase: usecase,
+		logger:  logger,
+		cfg:     cfg,
+		auth:    auth,
+	}
+}
+
+func (h *APIKeyHandler) RegisterRoutes(r *gin.Engine) {
+	apiKeyHandler := r.Group("/v1/api-keys", h.auth.Authorize(rbac.APIKeysManage))
+	{
+		apiKeyHandler.POST("", h.Create)
+		apiKeyHandler.GET("", h.List)
+		apiKeyHandler.DELETE("/:id", h.Revoke)
+	}
+}
+
+// Create godoc
+// @Summary Mint an API key
+// @Description Creates an API key for a machine client. The plaintext key is only returned in this response; it is stored hashed.
+// @Tags api-keys
+// @Accept json
+// @Produce json
+// @Param key body model.CreateAPIKeyRequest true "Key name and scopes, e.g. movies:write, actors:read"
+// @Success 201 {object} model.CreatedAPIKey
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /v1/api-keys [post]
+func (h *APIKeyHandler) Create(c *gin.Context) {
+	var req model.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid api key payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+	for _, scope := range req.Scopes {
+		if !rbac.Permission(scope).Valid() {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Unknown scope: " + scope, Code: "BAD_REQUEST"})
+			return
+		}
+	}
+
+	secret, err := randomHex(24)
+	if err != nil {
+		h.logger.Error("failed to generate api key: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to create API key", Code: "INTERNAL_ERROR"})
+		return
+	}
+	plain := apiKeyPrefix + secret
+
+	key := model.APIKey{
+		Name:    req.Name,
+		Prefix:  plain[:len(apiKeyPrefix)+8],
+		KeyHash: hashToken(plain),
+		Scopes:  req.Scopes,
+	}
+	if userID := currentUserID(c); userID != 0 {
+		key.CreatedBy = &userID
+	}
+
+	created, err := h.usecase.APIKeyRepo.Create(c.Request.Context(), key)
+	if err != nil {
+		h.logger.Error("failed to create api key: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to create API key", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, model.CreatedAPIKey{APIKey: created, Key: plain})
+}
+
+// List godoc
+// @Summary List API keys
+// @Description Lists all API keys, including revoked ones. Plaintext keys are never returned.
+// @Tags api-keys
+// @Produce json
+// @Success 200 {array} model.APIKey
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /v1/api-keys [get]
+func (h *APIKeyHandler) List(c *gin.Context) {
+	keys, err := h.usecase.APIKeyRepo.List(c.Request.Context())
+	if err != nil {
+		h.logger.Error("failed to list api keys: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to list API keys", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// Revoke godoc
+// @Summary Revoke an API key
+// @Description Revokes an API key; requests using it are rejected immediately
+// @Tags api-keys
+// @Produce json
+// @Param id path int true "API key ID"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /v1/api-keys/{id} [delete]
+func (h *APIKeyHandler) Revoke(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Error("invalid api key id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid API key ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	if err := h.usecase.APIKeyRepo.Revoke(c.Request.Context(), id); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "API key not found", Code: "NOT_FOUND"})
+			return
+		}
+		h.logger.Error("failed to revoke api key: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to revoke API key", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "API key revoked successfully"})
+}
@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/jwt"
+	"github.com/movie-app/pkg/logger"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type AuthHandler struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+	keys    *jwt.KeySet
+}
+
+func NewAuthHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, keys *jwt.KeySet) *AuthHandler {
+	return &AuthHandler{
+		usecase: usecase,
+		logger:  logger,
+		cfg:     cfg,
+		keys:    keys,
+	}
+}
+
+func (h *AuthHandler) RegisterRoutes(r *gin.Engine) {
+	authHandler := r.Group("/v1/auth")
+	{
+		authHandler.POST("/register", h.Register)
+		authHandler.POST("/login", h.Login)
+		authHandler.POST("/refresh", h.Refresh)
+		authHandler.POST("/logout", h.Logout)
+	}
+
+	r.GET("/.well-known/jwks.json", h.JWKS)
+}
+
+// Register godoc
+// @Summary Register a new user
+// @Description Creates a user account and returns an access token and a refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param user body model.RegisterRequest true "Credentials"
+// @Success 201 {object} model.AuthResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/auth/register [post]
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req model.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid register payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		h.logger.Error("failed to hash password: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to register user", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	user, err := h.usecase.UserRepo.Create(c.Request.Context(), model.User{
+		Email:        normalizeEmail(req.Email),
+		PasswordHash: string(hash),
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrEmailTaken) {
+			c.JSON(http.StatusConflict, model.ErrorResponse{Message: "Email is already registered", Code: "CONFLICT"})
+			return
+		}
+		h.logger.Error("failed to create user: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to register user", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	res, err := h.startSession(c.Request.Context(), user)
+	if err != nil {
+		h.logger.Error("failed to start session: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to issue token", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// Login godoc
+// @Summary Log in
+// @Description Verifies credentials and returns an access token and a refresh token
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body model.LoginRequest true "Credentials"
+// @Success 200 {object} model.AuthResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req model.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid login payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	user, err := h.usecase.UserRepo.GetByEmail(c.Request.Context(), normalizeEmail(req.Email))
+	if err != nil && !errors.Is(err, model.ErrNotFound) {
+		h.logger.Error("failed to fetch user: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to log in", Code: "INTERNAL_ERROR"})
+		return
+	}
+	if err != nil || bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)) != nil {
+		c.JSON(http.StatusUnauthorized, model.ErrorResponse{Message: "Invalid email or password", Code: "INVALID_CREDENTIALS"})
+		return
+	}
+
+	res, err := h.startSession(c.Request.Context(), user)
+	if err != nil {
+		h.logger.Error("failed to start session: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to issue token", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchanges a refresh token for a new access token and a new refresh token. The presented refresh token is invalidated; presenting it again revokes the whole session.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body model.RefreshRequest true "Refresh token"
+// @Success 200 {object} model.AuthResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req model.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid refresh payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	refreshToken, refreshHash, err := newRefreshToken()
+	if err != nil {
+		h.logger.Error("failed to generate refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to refresh token", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	next, err := h.usecase.RefreshTokenRepo.Rotate(c.Request.Context(), hashToken(req.RefreshToken), model.RefreshToken{
+		TokenHash: refreshHash,
+		ExpiresAt: time.Now().Add(h.cfg.RefreshTokenTTL),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrTokenReused):
+			c.JSON(http.StatusUnauthorized, model.ErrorResponse{Message: "Refresh token reuse detected, session revoked", Code: "TOKEN_REUSED"})
+		case errors.Is(err, model.ErrNotFound), errors.Is(err, model.ErrTokenExpired):
+			c.JSON(http.StatusUnauthorized, model.ErrorResponse{Message: "Invalid or expired refresh token", Code: "UNAUTHORIZED"})
+		default:
+			h.logger.Error("failed to rotate refresh token: %v", err)
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to refresh token", Code: "INTERNAL_ERROR"})
+		}
+		return
+	}
+
+	user, err := h.usecase.UserRepo.GetByID(c.Request.Context(), next.UserID)
+	if err != nil {
+		h.logger.Error("failed to fetch user: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to refresh token", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	res, err := h.buildResponse(user, next, refreshToken)
+	if err != nil {
+		h.logger.Error("failed to issue token: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to issue token", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revokes the session the refresh token belongs to. Access tokens issued for it stop working immediately.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body model.RefreshRequest true "Refresh token"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req model.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid logout payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	if err := h.usecase.RefreshTokenRepo.RevokeByHash(c.Request.Context(), hashToken(req.RefreshToken)); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusUnauthorized, model.ErrorResponse{Message: "Invalid refresh token", Code: "UNAUTHORIZED"})
+			return
+		}
+		h.logger.Error("failed to revoke refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to log out", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Logged out successfully"})
+}
+
+// JWKS godoc
+// @Summary JSON Web Key Set
+// @Description Public keys other services can use to verify access tokens, identified by kid
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwt.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keys.JWKS())
+}
+
+// startSession opens a new refresh token family for user.
+func (h *AuthHandler) startSession(ctx context.Context, user model.User) (model.AuthResponse, error) {
+	familyID, err := randomHex(16)
+	if err != nil {
+		return model.AuthResponse{}, err
+	}
+
+	refreshToken, refreshHash, err := newRefreshToken()
+	if err != nil {
+		return model.AuthResponse{}, err
+	}
+
+	stored, err := h.usecase.RefreshTokenRepo.Create(ctx, model.RefreshToken{
+		UserID:    user.ID,
+		FamilyID:  familyID,
+		TokenHash: refreshHash,
+		ExpiresAt: time.Now().Add(h.cfg.RefreshTokenTTL),
+	})
+	if err != nil {
+		return model.AuthResponse{}, err
+	}
+
+	return h.buildResponse(user, stored, refreshToken)
+}
+
+func (h *AuthHandler) buildResponse(user model.User, refresh model.RefreshToken, refreshToken string) (model.AuthResponse, error) {
+	now := time.Now()
+	expiresAt := now.Add(h.cfg.AccessTokenTTL)
+
+	token, err := h.keys.Sign(map[string]interface{}{
+		"sub":   strconv.Itoa(user.ID),
+		"sid":   refresh.FamilyID,
+		"email": user.Email,
+		"role":  user.Role,
+		"iat":   now.Unix(),
+		"exp":   expiresAt.Unix(),
+	})
+	if err != nil {
+		return model.AuthResponse{}, err
+	}
+
+	return model.AuthResponse{
+		AccessToken:      token,
+		TokenType:        "Bearer",
+		ExpiresAt:        expiresAt,
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: refresh.ExpiresAt,
+		User:             user,
+	}, nil
+}
+
+// newRefreshToken returns an opaque refresh token and the hash that is stored
+// in place of it.
+func newRefreshToken() (string, string, error) {
+	token, err := randomHex(32)
+	if err != nil {
+		return "", "", err
+	}
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
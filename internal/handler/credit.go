@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/rbac"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/logger"
+)
+
+type CreditHandler struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+	auth    *AuthMiddleware
+}
+
+func NewCreditHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware) *CreditHandler {
+	return &CreditHandler{
+		usecase: usecase,
+		logger:  logger,
+		cfg:     cfg,
+		auth:    auth,
+	}
+}
+
+func (h *CreditHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/v1/movies/:id/credits", h.auth.Optional(rbac.MoviesRead), h.GetMovieCredits)
+	r.GET("/v1/people/:id/filmography", h.auth.Optional(rbac.ActorsRead), h.GetFilmography)
+}
+
+// GetMovieCredits godoc
+// @Summary Get movie credits
+// @Description Returns the cast in billing order and the crew grouped by department
+// @Tags credits
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param If-None-Match header string false "ETag from a previous response; answered with 304 if unchanged"
+// @Success 200 {object} model.MovieCredits
+// @Success 304 {string} string "Not modified"
+// @Header 200 {string} ETag "Entity tag of the representation"
+// @Header 200 {string} Cache-Control "Caching policy configured for the route"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/movies/{id}/credits [get]
+func (h *CreditHandler) GetMovieCredits(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Error("invalid movie id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid movie ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	credits, err := h.usecase.CreditRepo.GetMovieCredits(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Movie not found", Code: "NOT_FOUND"})
+			return
+		}
+		h.logger.Error("failed to fetch movie credits: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch movie credits", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	writeCacheable(c, h.cfg, credits, 0, time.Time{})
+}
+
+// GetFilmography godoc
+// @Summary Get a person's filmography
+// @Description Returns every acting and crew credit of a person, newest movies first
+// @Tags credits
+// @Produce json
+// @Param id path int true "Person (actor) ID"
+// @Param If-None-Match header string false "ETag from a previous response; answered with 304 if unchanged"
+// @Success 200 {object} model.Filmography
+// @Success 304 {string} string "Not modified"
+// @Header 200 {string} ETag "Entity tag of the representation"
+// @Header 200 {string} Cache-Control "Caching policy configured for the route"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/people/{id}/filmography [get]
+func (h *CreditHandler) GetFilmography(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Error("invalid person id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid person ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	filmography, err := h.usecase.CreditRepo.GetFilmography(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Person not found", Code: "NOT_FOUND"})
+			return
+		}
+		h.logger.Error("failed to fetch filmography: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch filmography", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	writeCacheable(c, h.cfg, filmography, 0, time.Time{})
+}
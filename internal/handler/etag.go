@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+)
+
+// entityTag is the strong ETag of a representation: the resource version,
+// when it has one, and a digest of the encoded body. The digest changes with
+// data the version does not track, such as ratings or renamed cast members,
+// while If-Match only compares the version part.
+func entityTag(version int, body []byte) string {
+	sum := sha256.Sum256(body)
+	digest := hex.EncodeToString(sum[:8])
+	if version == 0 {
+		return `"` + digest + `"`
+	}
+	return `"` + strconv.Itoa(version) + "-" + digest + `"`
+}
+
+// writeTagged writes body as JSON with its ETag.
+func writeTagged(c *gin.Context, status int, body any, version int) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to encode response", Code: "INTERNAL_ERROR"})
+		return
+	}
+	c.Header("ETag", entityTag(version, data))
+	c.Data(status, gin.MIMEJSON+"; charset=utf-8", data)
+}
+
+// writeCacheable answers a read with body as JSON, tagged with its ETag, the
+// Last-Modified time when modified is set, and the Cache-Control policy
+// configured for the route. When the request's If-None-Match, or failing
+// that If-Modified-Since, shows the client already has this representation
+// it gets an empty 304 instead.
+//
+// Only pass modified when it moves with everything in body. Movies qualify
+// because triggers bump their updated_at for rating, cast, crew and genre
+// changes. Lists do not, since a deleted row changes a page without leaving
+// a newer timestamp behind, so they are revalidated by ETag alone.
+func writeCacheable(c *gin.Context, cfg *config.Config, body any, version int, modified time.Time) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to encode response", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	tag := entityTag(version, data)
+	c.Header("ETag", tag)
+	if !modified.IsZero() {
+		c.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+	c.Header("Cache-Control", cachePolicy(cfg, c.FullPath()))
+
+	if notModified(c, tag, modified) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	c.Data(http.StatusOK, gin.MIMEJSON+"; charset=utf-8", data)
+}
+
+func cachePolicy(cfg *config.Config, route string) string {
+	if policy, ok := cfg.CacheControl[route]; ok {
+		return policy
+	}
+	return cfg.CacheControlDefault
+}
+
+// notModified evaluates If-None-Match with the weak comparison RFC 9110
+// prescribes for it, and If-Modified-Since only when no If-None-Match was
+// sent.
+func notModified(c *gin.Context, tag string, modified time.Time) bool {
+	if header := c.GetHeader("If-None-Match"); header != "" {
+		for _, candidate := range strings.Split(header, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == tag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if header := c.GetHeader("If-Modified-Since"); header != "" && !modified.IsZero() {
+		since, err := http.ParseTime(header)
+		return err == nil && !modified.Truncate(time.Second).After(since)
+	}
+	return false
+}
+
+// ifMatch returns the version required by the If-Match header, or 0 when
+// any version will do because the header is absent or "*". When required is
+// set, a missing header is refused with 428. It writes the error response
+// itself and reports false when the request cannot proceed.
+func ifMatch(c *gin.Context, required bool) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	switch header {
+	case "":
+		if required {
+			c.JSON(http.StatusPreconditionRequired, model.ErrorResponse{Message: "If-Match header with the resource ETag is required", Code: "PRECONDITION_REQUIRED"})
+			return 0, false
+		}
+		return 0, true
+	case "*":
+		return 0, true
+	}
+
+	if strings.Contains(header, ",") {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "If-Match must contain a single entity tag", Code: "BAD_REQUEST"})
+		return 0, false
+	}
+	// Weak tags never match under the strong comparison If-Match requires,
+	// and neither does anything that is not a tag this API issued.
+	prefix, _, _ := strings.Cut(strings.Trim(header, `"`), "-")
+	version, err := strconv.Atoi(prefix)
+	if strings.HasPrefix(header, "W/") || err != nil || version < 1 {
+		return -1, true
+	}
+	return version, true
+}
+
+// preconditionFailed answers a request whose If-Match no longer holds with
+// the current representation, so the client can merge and retry.
+func preconditionFailed(c *gin.Context, current any, version int) {
+	writeTagged(c, http.StatusPreconditionFailed, current, version)
+}
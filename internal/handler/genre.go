@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/internal/rbac"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/logger"
+)
+
+type GenreHandler struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+	auth    *AuthMiddleware
+}
+
+func NewGenreHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware) *GenreHandler {
+	return &GenreHandler{
+		usecase: usecase,
+		logger:  logger,
+		cfg:     cfg,
+		auth:    auth,
+	}
+}
+
+func (h *GenreHandler) RegisterRoutes(r *gin.Engine) {
+	genreHandler := r.Group("/v1/genres")
+	{
+		genreHandler.POST("", h.auth.Authorize(rbac.GenresWrite), h.Create)
+		genreHandler.GET("/:id", h.auth.Optional(rbac.GenresRead), h.GetByID)
+		genreHandler.PUT("/:id", h.auth.Authorize(rbac.GenresWrite), h.Update)
+		genreHandler.GET("", h.auth.Optional(rbac.GenresRead), h.GetList)
+		genreHandler.DELETE("/:id", h.auth.Authorize(rbac.GenresDelete), h.Delete)
+	}
+}
+
+// Create godoc
+// @Summary Create a new genre
+// @Description Creates a genre and returns the created object
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param genre body model.GenreRequest true "Genre data"
+// @Success 201 {object} model.Genre
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /v1/genres [post]
+func (h *GenreHandler) Create(c *gin.Context) {
+	var req model.GenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid genre payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	res, err := h.usecase.GenreRepo.Create(c.Request.Context(), model.Genre{Name: req.Name})
+	if err != nil {
+		if errors.Is(err, model.ErrAlreadyExists) {
+			c.JSON(http.StatusConflict, model.ErrorResponse{Message: "Genre already exists", Code: "CONFLICT"})
+			return
+		}
+		h.logger.Error("failed to create genre: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to create genre", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// GetByID godoc
+// @Summary Get genre by ID
+// @Description Retrieves a genre by its ID
+// @Tags genres
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Param If-None-Match header string false "ETag from a previous response; answered with 304 if unchanged"
+// @Success 200 {object} model.Genre
+// @Success 304 {string} string "Not modified"
+// @Header 200 {string} ETag "Entity tag of the representation"
+// @Header 200 {string} Cache-Control "Caching policy configured for the route"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Router /v1/genres/{id} [get]
+func (h *GenreHandler) GetByID(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Error("invalid genre id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid genre ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	genre, err := h.usecase.GenreRepo.GetByID(c.Request.Context(), id)
+	if err != nil {
+		h.logger.Error("genre not found: %v", err)
+		c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Genre not found", Code: "NOT_FOUND"})
+		return
+	}
+
+	writeCacheable(c, h.cfg, genre, 0, genre.UpdatedAt)
+}
+
+// Update godoc
+// @Summary Update a genre
+// @Description Renames a genre by ID
+// @Tags genres
+// @Accept json
+// @Produce json
+// @Param id path int true "Genre ID"
+// @Param genre body model.GenreRequest true "Genre data"
+// @Success 200 {object} model.Genre
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /v1/genres/{id} [put]
+func (h *GenreHandler) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Error("invalid genre id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid genre ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	var req model.GenreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid update payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	updated, err := h.usecase.GenreRepo.Update(c.Request.Context(), model.Genre{ID: id, Name: req.Name})
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Genre not found", Code: "NOT_FOUND"})
+		case errors.Is(err, model.ErrAlreadyExists):
+			c.JSON(http.StatusConflict, model.ErrorResponse{Message: "Genre already exists", Code: "CONFLICT"})
+		default:
+			h.logger.Error("failed to update genre: %v", err)
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to update genre", Code: "INTERNAL_ERROR"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary Delete a genre
+// @Description Deletes a genre by ID and unlinks it from all movies
+// @Tags genres
+// @Param id path int true "Genre ID"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /v1/genres/{id} [delete]
+func (h *GenreHandler) Delete(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Error("invalid genre id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid genre ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	if err := h.usecase.GenreRepo.Delete(c.Request.Context(), id); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Genre not found", Code: "NOT_FOUND"})
+			return
+		}
+		h.logger.Error("failed to delete genre: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to delete genre", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Genre deleted successfully"})
+}
+
+// GetList godoc
+// @Summary Get a list of genres
+// @Description Retrieves a paginated list of genres ordered by name
+// @Tags genres
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page (default is 10, max 100)"
+// @Param name query string false "Search by genre name"
+// @Param If-None-Match header string false "ETag from a previous response; answered with 304 if unchanged"
+// @Success 200 {object} model.GenreList
+// @Success 304 {string} string "Not modified"
+// @Header 200 {string} ETag "Entity tag of the representation"
+// @Header 200 {string} Cache-Control "Caching policy configured for the route"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/genres [get]
+func (h *GenreHandler) GetList(c *gin.Context) {
+	var req model.GetListFilter
+
+	req.Page = parseInt(c.Query("page"), querybuilder.DefaultPage)
+	req.Limit = parseInt(c.Query("limit"), querybuilder.DefaultLimit)
+	if req.Page < 1 || req.Limit < 1 {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "page and limit must be positive integers", Code: "BAD_REQUEST"})
+		return
+	}
+	req.Limit = min(req.Limit, querybuilder.MaxLimit)
+
+	if name := c.Query("name"); name != "" {
+		req.Filters = append(req.Filters, model.Filter{
+			Column: "name",
+			Type:   "search",
+			Value:  name,
+		})
+	}
+
+	list, err := h.usecase.GenreRepo.GetList(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("failed to get genre list: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch genre list", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	writeCacheable(c, h.cfg, list, 0, time.Time{})
+}
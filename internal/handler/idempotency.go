@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/logger"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	maxIdempotencyKeyLen = 255
+)
+
+type IdempotencyMiddleware struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+}
+
+func NewIdempotencyMiddleware(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		usecase: usecase,
+		cfg:     cfg,
+		logger:  logger,
+	}
+}
+
+// Handle makes a route safe to retry. A request carrying an Idempotency-Key
+// header runs once per caller and key; retries within the TTL get the stored
+// response back with an Idempotent-Replayed header. Reusing a key with a
+// different request is refused with 422, and a retry that arrives while the
+// first request is still running gets 409. Server errors are not stored, so
+// the request can be retried with the same key.
+//
+// It must run after authentication, since keys are scoped to the caller.
+func (m *IdempotencyMiddleware) Handle() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if !validIdempotencyKey(key) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, model.ErrorResponse{
+				Message: "Idempotency-Key must be 1 to 255 printable ASCII characters",
+				Code:    "BAD_REQUEST",
+			})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, model.ErrorResponse{Message: "Failed to read request body", Code: "BAD_REQUEST"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		owner := idempotencyOwner(c)
+		fingerprint := requestFingerprint(c, body)
+
+		stored, reserved, err := m.usecase.IdempotencyRepo.Reserve(ctx, model.IdempotencyKey{
+			Owner:       owner,
+			Key:         key,
+			Fingerprint: fingerprint,
+			ExpiresAt:   time.Now().Add(m.cfg.IdempotencyKeyTTL),
+		})
+		if err != nil {
+			m.logger.Error("failed to reserve idempotency key: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to check Idempotency-Key", Code: "INTERNAL_ERROR"})
+			return
+		}
+
+		if !reserved {
+			switch {
+			case stored.Fingerprint != fingerprint:
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, model.ErrorResponse{
+					Message: "Idempotency-Key was already used for a different request",
+					Code:    "IDEMPOTENCY_KEY_REUSED",
+				})
+			case stored.StatusCode == nil:
+				c.Header("Retry-After", "1")
+				c.AbortWithStatusJSON(http.StatusConflict, model.ErrorResponse{
+					Message: "A request with this Idempotency-Key is still being processed",
+					Code:    "IDEMPOTENCY_KEY_IN_USE",
+				})
+			default:
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(*stored.StatusCode, stored.ContentType, stored.Response)
+				c.Abort()
+			}
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// The outcome is stored even if the client has gone away, since that
+		// is exactly when it will retry. Anything short of a stored response,
+		// a panic included, releases the key.
+		storeCtx := context.WithoutCancel(ctx)
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := m.usecase.IdempotencyRepo.Release(storeCtx, owner, key); err != nil {
+				m.logger.Error("failed to release idempotency key: %v", err)
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		if err := m.usecase.IdempotencyRepo.Complete(storeCtx, owner, key, status,
+			recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			m.logger.Error("failed to store idempotent response: %v", err)
+			return
+		}
+		completed = true
+	}
+}
+
+// idempotencyOwner scopes keys to the authenticated user or API key.
+func idempotencyOwner(c *gin.Context) string {
+	if id, ok := c.Get(ctxAPIKeyIDKey); ok {
+		return "key:" + strconv.Itoa(id.(int))
+	}
+	return "user:" + strconv.Itoa(currentUserID(c))
+}
+
+// requestFingerprint identifies the route and body of a request. JSON bodies
+// are re-encoded first, so retries that only differ in whitespace or member
+// order still match.
+func requestFingerprint(c *gin.Context, body []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		if canonical, err := json.Marshal(v); err == nil {
+			body = canonical
+		}
+	}
+
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method + " " + c.FullPath() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func validIdempotencyKey(key string) bool {
+	if len(key) > maxIdempotencyKeyLen {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x20 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// responseRecorder keeps a copy of the response body for replay.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
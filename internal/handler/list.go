@@ -0,0 +1,421 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/rbac"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/logger"
+)
+
+// watchlistAlias addresses the caller's default list in /v1/me/lists/{id}.
+const watchlistAlias = "watchlist"
+
+type ListHandler struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+	auth    *AuthMiddleware
+}
+
+func NewListHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware) *ListHandler {
+	return &ListHandler{
+		usecase: usecase,
+		logger:  logger,
+		cfg:     cfg,
+		auth:    auth,
+	}
+}
+
+func (h *ListHandler) RegisterRoutes(r *gin.Engine) {
+	listHandler := r.Group("/v1/me/lists", h.auth.AuthorizeUser(rbac.ListsWrite))
+	{
+		listHandler.GET("", h.GetMine)
+		listHandler.POST("", h.Create)
+		listHandler.GET("/:id", h.Get)
+		listHandler.PUT("/:id", h.Update)
+		listHandler.DELETE("/:id", h.Delete)
+		listHandler.POST("/:id/items", h.AddItem)
+		listHandler.DELETE("/:id/items/:movie_id", h.RemoveItem)
+		listHandler.PUT("/:id/items/order", h.Reorder)
+	}
+
+	r.GET("/v1/lists/:slug", h.auth.Optional(rbac.MoviesRead), h.GetShared)
+}
+
+// GetMine godoc
+// @Summary Get my lists
+// @Description Returns the caller's lists, starting with the default watchlist which is created on first use
+// @Tags lists
+// @Produce json
+// @Success 200 {array} model.List
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/lists [get]
+func (h *ListHandler) GetMine(c *gin.Context) {
+	lists, err := h.usecase.ListRepo.GetByUser(c.Request.Context(), currentUserID(c))
+	if err != nil {
+		h.logger.Error("failed to get lists: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch lists", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, lists)
+}
+
+// Create godoc
+// @Summary Create a list
+// @Description Creates a named movie list with a shareable slug. Lists are private unless visibility is "public".
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param list body model.ListRequest true "List data"
+// @Success 201 {object} model.List
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/lists [post]
+func (h *ListHandler) Create(c *gin.Context) {
+	var req model.ListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid list payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	list, err := h.usecase.ListRepo.Create(c.Request.Context(), model.List{
+		UserID:     currentUserID(c),
+		Name:       req.Name,
+		Visibility: req.Visibility,
+	})
+	if err != nil {
+		h.logger.Error("failed to create list: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to create list", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// Get godoc
+// @Summary Get one of my lists
+// @Description Returns a list owned by the caller with its movies in order. Use "watchlist" as the ID for the default list.
+// @Tags lists
+// @Produce json
+// @Param id path string true "List ID or \"watchlist\""
+// @Success 200 {object} model.List
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/lists/{id} [get]
+func (h *ListHandler) Get(c *gin.Context) {
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+	h.respondWithItems(c, http.StatusOK, list)
+}
+
+// GetShared godoc
+// @Summary Get a shared list
+// @Description Returns a public list by its slug. Private lists are only visible to their owner.
+// @Tags lists
+// @Produce json
+// @Param slug path string true "List slug"
+// @Success 200 {object} model.List
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/lists/{slug} [get]
+func (h *ListHandler) GetShared(c *gin.Context) {
+	list, err := h.usecase.ListRepo.Get(c.Request.Context(), model.Id{Slug: c.Param("slug")})
+	if err == nil && list.Visibility != model.ListVisibilityPublic && list.UserID != currentUserID(c) {
+		err = model.ErrNotFound
+	}
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "List not found", Code: "NOT_FOUND"})
+			return
+		}
+		h.logger.Error("failed to get list: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch list", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	h.respondWithItems(c, http.StatusOK, list)
+}
+
+// Update godoc
+// @Summary Rename a list
+// @Description Renames a list and optionally changes its visibility. The slug stays the same.
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID or \"watchlist\""
+// @Param list body model.ListRequest true "List data"
+// @Success 200 {object} model.List
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/lists/{id} [put]
+func (h *ListHandler) Update(c *gin.Context) {
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	var req model.ListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid list payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	updated, err := h.usecase.ListRepo.Update(c.Request.Context(), model.List{
+		ID:         list.ID,
+		Name:       req.Name,
+		Visibility: req.Visibility,
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "List not found", Code: "NOT_FOUND"})
+			return
+		}
+		h.logger.Error("failed to update list: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to update list", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary Delete a list
+// @Description Deletes a custom list. The default watchlist cannot be deleted.
+// @Tags lists
+// @Param id path int true "List ID"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/lists/{id} [delete]
+func (h *ListHandler) Delete(c *gin.Context) {
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.ListRepo.Delete(c.Request.Context(), list.ID); err != nil {
+		switch {
+		case errors.Is(err, model.ErrDefaultList):
+			c.JSON(http.StatusConflict, model.ErrorResponse{Message: "The watchlist cannot be deleted", Code: "CONFLICT"})
+		case errors.Is(err, model.ErrNotFound):
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "List not found", Code: "NOT_FOUND"})
+		default:
+			h.logger.Error("failed to delete list: %v", err)
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to delete list", Code: "INTERNAL_ERROR"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "List deleted successfully"})
+}
+
+// AddItem godoc
+// @Summary Add a movie to a list
+// @Description Inserts a movie at the given 1-based position, or appends it when position is omitted
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID or \"watchlist\""
+// @Param item body model.ListItemRequest true "Movie to add"
+// @Success 201 {object} model.List
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/lists/{id}/items [post]
+func (h *ListHandler) AddItem(c *gin.Context) {
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	var req model.ListItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid list item payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	if err := h.usecase.ListRepo.AddItem(c.Request.Context(), list.ID, req.MovieID, req.Position); err != nil {
+		switch {
+		case errors.Is(err, model.ErrAlreadyExists):
+			c.JSON(http.StatusConflict, model.ErrorResponse{Message: "Movie is already on the list", Code: "CONFLICT"})
+		case errors.Is(err, model.ErrNotFound):
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Movie not found", Code: "NOT_FOUND"})
+		default:
+			h.logger.Error("failed to add list item: %v", err)
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to add movie to list", Code: "INTERNAL_ERROR"})
+		}
+		return
+	}
+
+	h.respondWithItems(c, http.StatusCreated, list)
+}
+
+// RemoveItem godoc
+// @Summary Remove a movie from a list
+// @Tags lists
+// @Produce json
+// @Param id path string true "List ID or \"watchlist\""
+// @Param movie_id path int true "Movie ID"
+// @Success 200 {object} model.List
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/lists/{id}/items/{movie_id} [delete]
+func (h *ListHandler) RemoveItem(c *gin.Context) {
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	movieID, err := strconv.Atoi(c.Param("movie_id"))
+	if err != nil {
+		h.logger.Error("invalid movie id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid movie ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	if err := h.usecase.ListRepo.RemoveItem(c.Request.Context(), list.ID, movieID); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Movie is not on the list", Code: "NOT_FOUND"})
+			return
+		}
+		h.logger.Error("failed to remove list item: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to remove movie from list", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	h.respondWithItems(c, http.StatusOK, list)
+}
+
+// Reorder godoc
+// @Summary Reorder a list
+// @Description Sets the order of the list to movie_ids, which must contain every movie on the list exactly once
+// @Tags lists
+// @Accept json
+// @Produce json
+// @Param id path string true "List ID or \"watchlist\""
+// @Param order body model.ReorderListRequest true "New order"
+// @Success 200 {object} model.List
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/lists/{id}/items/order [put]
+func (h *ListHandler) Reorder(c *gin.Context) {
+	list, ok := h.ownedList(c)
+	if !ok {
+		return
+	}
+
+	var req model.ReorderListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid reorder payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	if err := h.usecase.ListRepo.Reorder(c.Request.Context(), list.ID, req.MovieIDs); err != nil {
+		switch {
+		case errors.Is(err, model.ErrInvalidOrder):
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: err.Error(), Code: "BAD_REQUEST"})
+		case errors.Is(err, model.ErrNotFound):
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "List not found", Code: "NOT_FOUND"})
+		default:
+			h.logger.Error("failed to reorder list: %v", err)
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to reorder list", Code: "INTERNAL_ERROR"})
+		}
+		return
+	}
+
+	h.respondWithItems(c, http.StatusOK, list)
+}
+
+// ownedList resolves the {id} path parameter to a list owned by the caller.
+// Other users' lists are reported as missing rather than forbidden.
+func (h *ListHandler) ownedList(c *gin.Context) (model.List, bool) {
+	var (
+		list model.List
+		err  error
+	)
+
+	userID := currentUserID(c)
+	if c.Param("id") == watchlistAlias {
+		list, err = h.usecase.ListRepo.GetDefault(c.Request.Context(), userID)
+	} else {
+		id, convErr := strconv.Atoi(c.Param("id"))
+		if convErr != nil {
+			h.logger.Error("invalid list id: %v", convErr)
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid list ID", Code: "BAD_REQUEST"})
+			return model.List{}, false
+		}
+		list, err = h.usecase.ListRepo.Get(c.Request.Context(), model.Id{ID: id})
+		if err == nil && list.UserID != userID {
+			err = model.ErrNotFound
+		}
+	}
+
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "List not found", Code: "NOT_FOUND"})
+			return model.List{}, false
+		}
+		h.logger.Error("failed to get list: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch list", Code: "INTERNAL_ERROR"})
+		return model.List{}, false
+	}
+	return list, true
+}
+
+// respondWithItems writes the list with its current items.
+func (h *ListHandler) respondWithItems(c *gin.Context, status int, list model.List) {
+	items, err := h.usecase.ListRepo.GetItems(c.Request.Context(), list.ID)
+	if err != nil {
+		h.logger.Error("failed to get list items: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch list items", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	list.Items = items
+	list.ItemCount = len(items)
+	c.JSON(status, list)
+}
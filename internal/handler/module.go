@@ -5,4 +5,16 @@ import "go.uber.org/fx"
 var Module = fx.Options(
 	fx.Provide(NewMovieHandler),
 	fx.Provide(NewActorHandler),
+	fx.Provide(NewGenreHandler),
+	fx.Provide(NewCreditHandler),
+	fx.Provide(NewReviewHandler),
+	fx.Provide(NewRankingHandler),
+	fx.Provide(NewSearchHandler),
+	fx.Provide(NewListHandler),
+	fx.Provide(NewWatchHandler),
+	fx.Provide(NewAuthHandler),
+	fx.Provide(NewUserHandler),
+	fx.Provide(NewAPIKeyHandler),
+	fx.Provide(NewAuthMiddleware),
+	fx.Provide(NewIdempotencyMiddleware),
 )
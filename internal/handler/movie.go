@@ -3,39 +3,48 @@ package handler
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator"
 	"github.com/movie-app/internal/config"
 	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/internal/rbac"
 	"github.com/movie-app/internal/usecase"
 	"github.com/movie-app/pkg/logger"
 	"github.com/spf13/cast"
 )
 
 type MovieHandler struct {
-	usecase *usecase.UseCase
-	cfg     *config.Config
-	logger  *logger.Logger
+	usecase     *usecase.UseCase
+	cfg         *config.Config
+	logger      *logger.Logger
+	auth        *AuthMiddleware
+	idempotency *IdempotencyMiddleware
 }
 
-func NewMovieHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger) *MovieHandler {
+func NewMovieHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware, idempotency *IdempotencyMiddleware) *MovieHandler {
 	return &MovieHandler{
-		usecase: usecase,
-		logger:  logger,
-		cfg:     cfg,
+		usecase:     usecase,
+		logger:      logger,
+		cfg:         cfg,
+		auth:        auth,
+		idempotency: idempotency,
 	}
 }
 
 func (h *MovieHandler) RegisterRoutes(r *gin.Engine) {
 	movieHandler := r.Group("/v1/movies")
 	{
-		movieHandler.POST("", h.Create)
-		movieHandler.GET("/:id", h.GetByID)
-		movieHandler.PUT("/:id", h.Update)
-		movieHandler.GET("", h.GetAll)
-		movieHandler.PUT("/field")
-		movieHandler.DELETE("/:id", h.Delete)
+		movieHandler.POST("", h.auth.Authorize(rbac.MoviesWrite), h.idempotency.Handle(), h.Create)
+		movieHandler.GET("/:id", h.auth.Optional(rbac.MoviesRead), h.GetByID)
+		movieHandler.PUT("/:id", h.auth.Authorize(rbac.MoviesWrite), h.Update)
+		movieHandler.PATCH("/:id", h.auth.Authorize(rbac.MoviesWrite), h.Patch)
+		movieHandler.GET("", h.auth.Optional(rbac.MoviesRead), h.GetAll)
+		movieHandler.PUT("/field", h.auth.Authorize(rbac.MoviesWrite), h.UpdateField)
+		movieHandler.DELETE("/:id", h.auth.Authorize(rbac.MoviesDelete), h.Delete)
 	}
 }
 
@@ -45,14 +54,22 @@ func (h *MovieHandler) RegisterRoutes(r *gin.Engine) {
 // @Accept json
 // @Produce json
 // @Param movie body model.CreateMovieRequest true "Movie data"
+// @Param Idempotency-Key header string false "Makes the request safe to retry; a retry with the same key replays the first response"
 // @Success 201 {object} model.Movie
+// @Header 201 {string} Idempotent-Replayed "Set when the response is a replay"
 // @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse "A request with the same Idempotency-Key is still running"
+// @Failure 422 {object} model.ErrorResponse "Idempotency-Key reused with a different body, or a referenced actor, person or genre does not exist"
 // @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /v1/movies [post]
 func (h *MovieHandler) Create(c *gin.Context) {
 	var (
 		movie model.CreateMovieRequest
-		cast  []model.Actor
+		cast  []model.CastMember
 	)
 
 	if err := c.ShouldBindJSON(&movie); err != nil {
@@ -70,18 +87,26 @@ func (h *MovieHandler) Create(c *gin.Context) {
 		return
 	}
 
-	for _, castInput := range movie.Casts {
-		cast = append(cast, model.Actor{ID: castInput.Id})
+	cast = castMembers(movie.Casts)
+
+	var genres []model.Genre
+	for _, genreInput := range movie.Genres {
+		genres = append(genres, model.Genre{ID: genreInput.Id})
 	}
 
 	createdMovie, err := h.usecase.MovieRepo.Create(c.Request.Context(), model.Movie{
-		Title:    movie.Title,
-		Director: movie.Director,
-		Plot:     movie.Plot,
-		Year:     movie.Year,
-		Cast:     cast,
+		Title:  movie.Title,
+		Plot:   movie.Plot,
+		Year:   movie.Year,
+		Cast:   cast,
+		Crew:   crewMembers(movie.Crew),
+		Genres: genres,
 	})
 	if err != nil {
+		if errors.Is(err, model.ErrUnknownReference) {
+			c.JSON(422, model.ErrorResponse{Message: err.Error(), Code: "UNPROCESSABLE_ENTITY"})
+			return
+		}
 		h.logger.Error(fmt.Sprintf("Failed to create movie: %v", err))
 		c.JSON(500, gin.H{"error": "Failed to create movie"})
 		return
@@ -95,7 +120,13 @@ func (h *MovieHandler) Create(c *gin.Context) {
 // @Tags movies
 // @Produce json
 // @Param id path int true "Movie ID"
+// @Param If-None-Match header string false "ETag from a previous response; answered with 304 if unchanged"
+// @Param If-Modified-Since header string false "Last-Modified from a previous response; answered with 304 if unchanged and If-None-Match is absent"
 // @Success 200 {object} model.Movie
+// @Success 304 {string} string "Not modified"
+// @Header 200 {string} ETag "Entity tag of the representation"
+// @Header 200 {string} Last-Modified "Last change to the movie, its ratings, cast, crew or genres"
+// @Header 200 {string} Cache-Control "Caching policy configured for the route"
 // @Failure 400 {object} model.ErrorResponse
 // @Failure 404 {object} model.ErrorResponse
 // @Router /v1/movies/{id} [get]
@@ -111,7 +142,7 @@ func (h *MovieHandler) GetByID(c *gin.Context) {
 		c.JSON(404, gin.H{"error": "Movie not found"})
 		return
 	}
-	c.JSON(200, movie)
+	writeCacheable(c, h.cfg, movie, movie.Version, movie.UpdatedAt)
 }
 
 // @Summary Update movie
@@ -121,9 +152,18 @@ func (h *MovieHandler) GetByID(c *gin.Context) {
 // @Produce json
 // @Param id path int true "Movie ID"
 // @Param movie body model.UpdateMovieRequest true "Updated movie"
+// @Param If-Match header string false "ETag from a previous response; the change is refused with 412 if the resource was modified since"
 // @Success 200 {object} model.Movie
+// @Header 200 {string} ETag "Version tag to send back in If-Match"
 // @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 412 {object} model.Movie "The current representation"
+// @Failure 422 {object} model.ErrorResponse "A referenced actor, person or genre does not exist"
+// @Failure 428 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /v1/movies/{id} [put]
 func (h *MovieHandler) Update(c *gin.Context) {
 	id := cast.ToInt(c.Param("id"))
@@ -132,6 +172,11 @@ func (h *MovieHandler) Update(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatch(c, h.cfg.RequireIfMatch)
+	if !ok {
+		return
+	}
+
 	var req model.UpdateMovieRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error(fmt.Sprintf("Failed to bind update movie data: %v", err))
@@ -139,28 +184,98 @@ func (h *MovieHandler) Update(c *gin.Context) {
 		return
 	}
 
-	var cast []model.Actor
-	for _, actor := range req.Casts {
-		cast = append(cast, model.Actor{ID: actor.Id})
+	cast := castMembers(req.Casts)
+
+	var genres []model.Genre
+	for _, genre := range req.Genres {
+		genres = append(genres, model.Genre{ID: genre.Id})
 	}
 
 	movie := model.Movie{
-		ID:       id,
-		Title:    req.Title,
-		Director: req.Director,
-		Plot:     req.Plot,
-		Year:     req.Year,
-		Cast:     cast,
+		ID:      id,
+		Title:   req.Title,
+		Plot:    req.Plot,
+		Year:    req.Year,
+		Cast:    cast,
+		Crew:    crewMembers(req.Crew),
+		Genres:  genres,
+		Version: version,
 	}
 
 	updatedMovie, err := h.usecase.MovieRepo.Update(c.Request.Context(), movie)
 	if err != nil {
-		h.logger.Error(fmt.Sprintf("Failed to update movie: %v", err))
-		c.JSON(500, gin.H{"error": "Failed to update movie"})
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			c.JSON(404, gin.H{"error": "Movie not found"})
+		case errors.Is(err, model.ErrVersionMismatch):
+			h.preconditionFailed(c, id)
+		case errors.Is(err, model.ErrUnknownReference):
+			c.JSON(422, model.ErrorResponse{Message: err.Error(), Code: "UNPROCESSABLE_ENTITY"})
+		default:
+			h.logger.Error(fmt.Sprintf("Failed to update movie: %v", err))
+			c.JSON(500, gin.H{"error": "Failed to update movie"})
+		}
 		return
 	}
 
-	c.JSON(200, updatedMovie)
+	writeTagged(c, 200, updatedMovie, updatedMovie.Version)
+}
+
+// @Summary Partially update a movie
+// @Description Applies a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902) to the movie document {title, year, plot, cast, crew, genres}.
+// @Description cast is an object keyed by actor ID, so {"cast": {"12": {"character_name": "Neo"}, "7": null}} adds or changes actor 12 and removes actor 7; with JSON Patch use paths like /cast/12.
+// @Description crew is an array of {id, department, job} and genres an array of genre IDs; both are replaced as a whole.
+// @Description Only the parts that change are written. A failed "test" operation returns 409.
+// @Tags movies
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param patch body model.MovieDocument true "Merge patch, or an array of JSON Patch operations"
+// @Param If-Match header string false "ETag from a previous response; the change is refused with 412 if the resource was modified since"
+// @Success 200 {object} model.Movie
+// @Header 200 {string} ETag "Version tag to send back in If-Match"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 415 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 412 {object} model.Movie "The current representation"
+// @Failure 428 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /v1/movies/{id} [patch]
+func (h *MovieHandler) Patch(c *gin.Context) {
+	id := cast.ToInt(c.Param("id"))
+	if id == 0 {
+		c.JSON(400, model.ErrorResponse{Message: "id must be provided", Code: "BAD_REQUEST"})
+		return
+	}
+
+	version, ok := ifMatch(c, h.cfg.RequireIfMatch)
+	if !ok {
+		return
+	}
+
+	patch, ok := readPatch[model.MovieDocument](c)
+	if !ok {
+		return
+	}
+
+	movie, err := h.usecase.MovieRepo.Patch(c.Request.Context(), id, version, patch)
+	if err != nil {
+		if errors.Is(err, model.ErrVersionMismatch) {
+			h.preconditionFailed(c, id)
+			return
+		}
+		patchFailed(c, h.logger, err, "Movie")
+		return
+	}
+
+	writeTagged(c, 200, movie, movie.Version)
 }
 
 // @Summary Delete movie
@@ -168,9 +283,16 @@ func (h *MovieHandler) Update(c *gin.Context) {
 // @Tags movies
 // @Produce json
 // @Param id path int true "Movie ID"
+// @Param If-Match header string false "ETag from a previous response; the change is refused with 412 if the resource was modified since"
 // @Success 200 {object} map[string]any{}
 // @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 412 {object} model.Movie "The current representation"
+// @Failure 428 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /v1/movies/{id} [delete]
 func (h *MovieHandler) Delete(c *gin.Context) {
 	id := cast.ToInt(c.Param("id"))
@@ -178,85 +300,183 @@ func (h *MovieHandler) Delete(c *gin.Context) {
 		c.JSON(400, gin.H{"error": "id must be provided"})
 		return
 	}
-	err := h.usecase.MovieRepo.Delete(c.Request.Context(), model.Id{ID: id})
+	version, ok := ifMatch(c, h.cfg.RequireIfMatch)
+	if !ok {
+		return
+	}
+	err := h.usecase.MovieRepo.Delete(c.Request.Context(), model.Id{ID: id}, version)
 	if err != nil {
-		h.logger.Error(fmt.Sprintf("Failed to delete movie: %v", err))
-		c.JSON(500, gin.H{"error": "Failed to delete movie"})
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			c.JSON(404, gin.H{"error": "Movie not found"})
+		case errors.Is(err, model.ErrVersionMismatch):
+			h.preconditionFailed(c, id)
+		default:
+			h.logger.Error(fmt.Sprintf("Failed to delete movie: %v", err))
+			c.JSON(500, gin.H{"error": "Failed to delete movie"})
+		}
 		return
 	}
 	c.JSON(200, gin.H{"message": "Movie deleted successfully"})
 }
 
+// preconditionFailed responds 412 with the movie as it is now.
+func (h *MovieHandler) preconditionFailed(c *gin.Context, id int) {
+	movie, err := h.usecase.MovieRepo.GetSingle(c.Request.Context(), model.Id{ID: id})
+	if err != nil {
+		h.logger.Error(fmt.Sprintf("Failed to fetch movie after version mismatch: %v", err))
+		c.JSON(500, gin.H{"error": "Failed to fetch movie"})
+		return
+	}
+	preconditionFailed(c, movie, movie.Version)
+}
+
 // @Summary Get all movies
-// @Description Get a paginated list of movies with optional filters and ordering
+// @Description Get a paginated list of movies with optional filters and ordering.
+// @Description Any field can be filtered with field[op]=value where op is one of eq, ne, gt, gte, lt, lte, search, in, between, is_null (e.g. year[between]=1990,1999, genre[in]=1,2).
+// @Description Fields: id, title, year, plot, average_rating, rating_count, created_at, updated_at, genre, director, actor_id.
 // @Tags movies
 // @Produce json
 // @Param page query int false "Page number (default is 1)"
-// @Param limit query int false "Items per page (default is 10)"
+// @Param limit query int false "Items per page (default is 10, max 100)"
 // @Param title query string false "Search by movie title"
-// @Param director query string false "Search by director name"
-// @Param year query string false "Search by release year"
-// @Param order_by query string false "Field to order by (e.g. year)"
-// @Param sort query string false "Sort direction: asc or desc (default asc)"
+// @Param director query string false "Search by director name (directing credits)"
+// @Param year query int false "Filter by release year"
+// @Param genre query string false "Only movies in this genre (ID or name)"
+// @Param actor_id query string false "Comma separated actor IDs; movies with any of them. Use actor_id[all]=1,2 to require all"
+// @Param sort query string false "Comma separated sort keys, prefix with - for descending (e.g. -average_rating,title)"
+// @Param order_by query string false "Deprecated: field to order by, with sort=asc|desc as direction"
+// @Param after query string false "Cursor from next_cursor of the previous response; returns the following page"
+// @Param before query string false "Cursor from prev_cursor of the previous response; returns the preceding page"
+// @Param count query bool false "Set to false to skip counting the total"
+// @Param facets query string false "Comma separated facets to count over the filtered movies: genre, decade, director"
+// @Param If-None-Match header string false "ETag from a previous response; answered with 304 if unchanged"
 // @Success 200 {object} model.MovieList
+// @Success 304 {string} string "Not modified"
+// @Header 200 {string} ETag "Entity tag of the representation"
+// @Header 200 {string} Cache-Control "Caching policy configured for the route"
+// @Failure 400 {object} model.ErrorResponse
 // @Failure 500 {object} model.ErrorResponse
 // @Router /v1/movies [get]
 func (h *MovieHandler) GetAll(c *gin.Context) {
-	var req model.GetListFilter
-
-	req.Page = parseInt(c.DefaultQuery("page", "1"), 1)
-	req.Limit = parseInt(c.DefaultQuery("limit", "10"), 10)
-
-	for key, values := range c.Request.URL.Query() {
-		switch key {
-		case "title", "director", "year":
-			req.Filters = append(req.Filters, model.Filter{
-				Column: key,
-				Type:   "search",
-				Value:  values[0],
-			})
-		}
+	query := c.Request.URL.Query()
+
+	// order_by=year&sort=desc predates the sort=-year syntax and is still
+	// accepted.
+	var legacyOrder []model.OrderBy
+	if orderBy := query.Get("order_by"); orderBy != "" {
+		legacyOrder = append(legacyOrder, model.OrderBy{Column: orderBy, Order: c.DefaultQuery("sort", "asc")})
+		query.Del("order_by")
+		query.Del("sort")
 	}
 
-	orderBy := c.Query("order_by")
-	sort := c.DefaultQuery("sort", "asc")
-	if orderBy != "" {
-		req.OrderBy = append(req.OrderBy, model.OrderBy{
-			Column: orderBy,
-			Order:  sort,
-		})
+	req, err := querybuilder.ParseQuery(query, querybuilder.QueryOptions{
+		DefaultOps: movieDefaultOps,
+		Reserved:   []string{"facets"},
+	})
+	if err != nil {
+		c.JSON(400, model.ErrorResponse{Message: err.Error(), Code: "BAD_REQUEST"})
+		return
+	}
+	req.OrderBy = append(req.OrderBy, legacyOrder...)
+	if facets := query.Get("facets"); facets != "" {
+		for _, f := range strings.Split(facets, ",") {
+			req.Facets = append(req.Facets, strings.TrimSpace(f))
+		}
 	}
 
 	// Call repo
 	movies, err := h.usecase.MovieRepo.GetList(c.Request.Context(), req)
 	if err != nil {
+		if errors.Is(err, model.ErrInvalidFilter) {
+			c.JSON(400, model.ErrorResponse{Message: err.Error(), Code: "BAD_REQUEST"})
+			return
+		}
 		h.logger.Error(fmt.Sprintf("Failed to fetch movies: %v", err))
 		c.JSON(500, gin.H{"error": "Failed to fetch movies"})
 		return
 	}
 
-	c.JSON(200, movies)
+	writeCacheable(c, h.cfg, movies, 0, time.Time{})
+}
+
+// movieDefaultOps keeps ?title= and ?director= as substring searches and
+// lets ?actor_id=1,2 match movies with any of the actors.
+var movieDefaultOps = map[string]querybuilder.Operator{
+	"title":    querybuilder.Search,
+	"director": querybuilder.Search,
+	"actor_id": querybuilder.In,
+}
+
+// castMembers converts the request cast, defaulting billing order to the
+// 1-based position in the array.
+func castMembers(casts []model.CastRequest) []model.CastMember {
+	members := make([]model.CastMember, 0, len(casts))
+	for i, castInput := range casts {
+		billingOrder := castInput.BillingOrder
+		if billingOrder == 0 {
+			billingOrder = i + 1
+		}
+		members = append(members, model.CastMember{
+			Actor:         model.Actor{ID: castInput.Id},
+			CharacterName: castInput.CharacterName,
+			BillingOrder:  billingOrder,
+		})
+	}
+	return members
+}
+
+func crewMembers(crew []model.CrewRequest) []model.CrewMember {
+	members := make([]model.CrewMember, 0, len(crew))
+	for _, crewInput := range crew {
+		members = append(members, model.CrewMember{
+			Actor:      model.Actor{ID: crewInput.Id},
+			Department: crewInput.Department,
+			Job:        crewInput.Job,
+		})
+	}
+	return members
 }
 
-// func (h *MovieHandler) UpdateField(c *gin.Context) {
-// 	// Expecting JSON like: { "id": "movie_id", "field": "title", "value": "New Title" }
-// 	var req struct {
-// 		ID    string `json:"id"`
-// 		Field string `json:"field"`
-// 		Value string `json:"value"`
-// 	}
-
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		h.logger.Error(fmt.Sprintf("Invalid update field payload: %v", err))
-// 		c.JSON(400, gin.H{"error": "Invalid request body"})
-// 		return
-// 	}
-
-// 	err := h.usecase.MovieRepo.UpdateField(c.Request.Context(), req.ID, req.Field, req.Value)
-// 	if err != nil {
-// 		h.logger.Errorf("Failed to update field: %v", err)
-// 		c.JSON(500, gin.H{"error": "Failed to update movie field"})
-// 		return
-// 	}
-// 	c.JSON(200, gin.H{"message": "Field updated successfully"})
-// }
+// @Summary Update fields on matching movies
+// @Description Sets the given fields on every movie matching all filters and bumps updated_at.
+// @Description Filters use the list filter fields and operators, e.g. {"column": "year", "type": "lt", "value": "1950"}. At least one filter is required.
+// @Description Updatable fields: title, year, plot. Values are converted to the field type; numbers may be sent as strings and only plot may be null.
+// @Description Requests matching more than the configured row cap are rejected without changing anything. Set dry_run to get the IDs that would change.
+// @Tags movies
+// @Accept json
+// @Produce json
+// @Param request body model.UpdateFieldRequest true "Filters and field values"
+// @Success 200 {object} model.RowsEffected
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 422 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /v1/movies/field [put]
+func (h *MovieHandler) UpdateField(c *gin.Context) {
+	var req model.UpdateFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(fmt.Sprintf("Invalid update field payload: %v", err))
+		c.JSON(400, model.ErrorResponse{Message: "Invalid request body", Code: "BAD_REQUEST"})
+		return
+	}
+
+	res, err := h.usecase.MovieRepo.UpdateField(c.Request.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrInvalidFilter):
+			c.JSON(400, model.ErrorResponse{Message: err.Error(), Code: "BAD_REQUEST"})
+		case errors.Is(err, model.ErrTooManyRows):
+			c.JSON(422, model.ErrorResponse{Message: err.Error(), Code: "TOO_MANY_ROWS"})
+		default:
+			h.logger.Error(fmt.Sprintf("Failed to update movie fields: %v", err))
+			c.JSON(500, model.ErrorResponse{Message: "Failed to update movie fields", Code: "INTERNAL_ERROR"})
+		}
+		return
+	}
+
+	c.JSON(200, res)
+}
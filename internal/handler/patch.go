@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/pkg/jsonpatch"
+	"github.com/movie-app/pkg/logger"
+)
+
+// readPatch reads a PATCH body and returns a function that applies it to a
+// document. Merge patches (also accepted as plain application/json) and
+// JSON Patches are supported. It writes the error response itself and
+// reports false when the body cannot be used.
+//
+// Applying fails with jsonpatch.ErrTestFailed when a "test" operation does
+// not match, and with model.ErrInvalidPatch when an operation cannot be
+// applied or the patched document does not validate.
+func readPatch[T any](c *gin.Context) (func(T) (T, error), bool) {
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Failed to read request body", Code: "BAD_REQUEST"})
+		return nil, false
+	}
+
+	var apply func(doc []byte) ([]byte, error)
+	switch c.ContentType() {
+	case jsonpatch.MergePatchType, binding.MIMEJSON:
+		if trimmed := bytes.TrimSpace(body); !json.Valid(trimmed) || len(trimmed) == 0 || trimmed[0] != '{' {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Merge patch must be a JSON object", Code: "BAD_REQUEST"})
+			return nil, false
+		}
+		apply = func(doc []byte) ([]byte, error) { return jsonpatch.MergePatch(doc, body) }
+	case jsonpatch.JSONPatchType:
+		patch, err := jsonpatch.Decode(body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: err.Error(), Code: "BAD_REQUEST"})
+			return nil, false
+		}
+		apply = patch.Apply
+	default:
+		c.Header("Accept-Patch", jsonpatch.MergePatchType+", "+jsonpatch.JSONPatchType)
+		c.JSON(http.StatusUnsupportedMediaType, model.ErrorResponse{
+			Message: fmt.Sprintf("Content-Type must be %s or %s", jsonpatch.MergePatchType, jsonpatch.JSONPatchType),
+			Code:    "UNSUPPORTED_MEDIA_TYPE",
+		})
+		return nil, false
+	}
+
+	return func(doc T) (T, error) {
+		var out T
+		current, err := json.Marshal(doc)
+		if err != nil {
+			return out, err
+		}
+		patched, err := apply(current)
+		if err != nil {
+			if errors.Is(err, jsonpatch.ErrTestFailed) {
+				return out, err
+			}
+			return out, fmt.Errorf("%w: %v", model.ErrInvalidPatch, err)
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(patched))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&out); err != nil {
+			return out, fmt.Errorf("%w: %v", model.ErrInvalidPatch, err)
+		}
+		if err := binding.Validator.ValidateStruct(&out); err != nil {
+			return out, fmt.Errorf("%w: %v", model.ErrInvalidPatch, err)
+		}
+		return out, nil
+	}, true
+}
+
+// patchFailed writes the response for an error returned by a repository
+// Patch call.
+func patchFailed(c *gin.Context, log *logger.Logger, err error, entity string) {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		c.JSON(http.StatusNotFound, model.ErrorResponse{Message: entity + " not found", Code: "NOT_FOUND"})
+	case errors.Is(err, jsonpatch.ErrTestFailed):
+		c.JSON(http.StatusConflict, model.ErrorResponse{Message: err.Error(), Code: "CONFLICT"})
+	case errors.Is(err, model.ErrInvalidPatch):
+		c.JSON(http.StatusUnprocessableEntity, model.ErrorResponse{Message: err.Error(), Code: "UNPROCESSABLE_ENTITY"})
+	default:
+		log.Error("failed to patch %s: %v", strings.ToLower(entity), err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to update " + strings.ToLower(entity), Code: "INTERNAL_ERROR"})
+	}
+}
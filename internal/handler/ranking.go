@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/internal/rbac"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/logger"
+)
+
+type RankingHandler struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+	auth    *AuthMiddleware
+}
+
+func NewRankingHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware) *RankingHandler {
+	return &RankingHandler{
+		usecase: usecase,
+		logger:  logger,
+		cfg:     cfg,
+		auth:    auth,
+	}
+}
+
+func (h *RankingHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/v1/movies/top", h.auth.Optional(rbac.MoviesRead), h.GetTop)
+}
+
+// GetTop godoc
+// @Summary Get top rated movies
+// @Description Ranks movies by an IMDb-style weighted rating that pulls movies with few votes towards the mean of all votes. Movies below the vote threshold are left out. Rankings come from a snapshot refreshed periodically.
+// @Tags movies
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page (default is 10, max 100)"
+// @Param min_votes query int false "Minimum number of votes (defaults to server setting)"
+// @Param genre query string false "Only movies in this genre (ID or name)"
+// @Param year_from query int false "Released in or after this year"
+// @Param year_to query int false "Released in or before this year"
+// @Param If-None-Match header string false "ETag from a previous response; answered with 304 if unchanged"
+// @Success 200 {object} model.TopRatedList
+// @Success 304 {string} string "Not modified"
+// @Header 200 {string} ETag "Entity tag of the representation"
+// @Header 200 {string} Cache-Control "Caching policy configured for the route"
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/movies/top [get]
+func (h *RankingHandler) GetTop(c *gin.Context) {
+	req := model.TopRatedFilter{
+		Page:     parseInt(c.Query("page"), querybuilder.DefaultPage),
+		Limit:    parseInt(c.Query("limit"), querybuilder.DefaultLimit),
+		MinVotes: parseInt(c.Query("min_votes"), h.cfg.TopRatedMinVotes),
+		Genre:    c.Query("genre"),
+		YearFrom: parseInt(c.Query("year_from"), 0),
+		YearTo:   parseInt(c.Query("year_to"), 0),
+	}
+
+	if req.Page < 1 || req.Limit < 1 {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "page and limit must be positive integers", Code: "BAD_REQUEST"})
+		return
+	}
+	req.Limit = min(req.Limit, querybuilder.MaxLimit)
+	if req.MinVotes < 0 {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "min_votes must not be negative", Code: "BAD_REQUEST"})
+		return
+	}
+	if req.YearFrom != 0 && req.YearTo != 0 && req.YearFrom > req.YearTo {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "year_from must not be after year_to", Code: "BAD_REQUEST"})
+		return
+	}
+
+	list, err := h.usecase.RankingRepo.GetTop(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("failed to get top rated movies: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch top rated movies", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	writeCacheable(c, h.cfg, list, 0, time.Time{})
+}
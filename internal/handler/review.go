@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/internal/rbac"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/logger"
+)
+
+type ReviewHandler struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+	auth    *AuthMiddleware
+}
+
+func NewReviewHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware) *ReviewHandler {
+	return &ReviewHandler{
+		usecase: usecase,
+		logger:  logger,
+		cfg:     cfg,
+		auth:    auth,
+	}
+}
+
+func (h *ReviewHandler) RegisterRoutes(r *gin.Engine) {
+	reviewHandler := r.Group("/v1/movies/:id/reviews")
+	{
+		reviewHandler.GET("", h.auth.Optional(rbac.MoviesRead), h.GetList)
+		reviewHandler.POST("", h.auth.AuthorizeUser(rbac.ReviewsWrite), h.Create)
+		reviewHandler.PUT("/me", h.auth.AuthorizeUser(rbac.ReviewsWrite), h.Update)
+		reviewHandler.DELETE("/me", h.auth.AuthorizeUser(rbac.ReviewsWrite), h.Delete)
+	}
+}
+
+// Create godoc
+// @Summary Review a movie
+// @Description Posts the current user's rating (1-10) and optional text for a movie. Each user can review a movie once.
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param review body model.ReviewRequest true "Review data"
+// @Success 201 {object} model.Review
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 409 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/movies/{id}/reviews [post]
+func (h *ReviewHandler) Create(c *gin.Context) {
+	movieID, userID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req model.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid review payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	res, err := h.usecase.ReviewRepo.Create(c.Request.Context(), model.Review{
+		MovieID: movieID,
+		UserID:  userID,
+		Rating:  req.Rating,
+		Body:    req.Body,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrAlreadyExists):
+			c.JSON(http.StatusConflict, model.ErrorResponse{Message: "Movie already reviewed", Code: "CONFLICT"})
+		case errors.Is(err, model.ErrNotFound):
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Movie not found", Code: "NOT_FOUND"})
+		default:
+			h.logger.Error("failed to create review: %v", err)
+			c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to create review", Code: "INTERNAL_ERROR"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, res)
+}
+
+// Update godoc
+// @Summary Edit own review
+// @Description Updates the current user's rating and text for a movie
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param review body model.ReviewRequest true "Review data"
+// @Success 200 {object} model.Review
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/movies/{id}/reviews/me [put]
+func (h *ReviewHandler) Update(c *gin.Context) {
+	movieID, userID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req model.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid review payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	updated, err := h.usecase.ReviewRepo.Update(c.Request.Context(), model.Review{
+		MovieID: movieID,
+		UserID:  userID,
+		Rating:  req.Rating,
+		Body:    req.Body,
+	})
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Review not found", Code: "NOT_FOUND"})
+			return
+		}
+		h.logger.Error("failed to update review: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to update review", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// Delete godoc
+// @Summary Delete own review
+// @Description Removes the current user's review of a movie
+// @Tags reviews
+// @Param id path int true "Movie ID"
+// @Success 200 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 404 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/movies/{id}/reviews/me [delete]
+func (h *ReviewHandler) Delete(c *gin.Context) {
+	movieID, userID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.ReviewRepo.Delete(c.Request.Context(), movieID, userID); err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			c.JSON(http.StatusNotFound, model.ErrorResponse{Message: "Review not found", Code: "NOT_FOUND"})
+			return
+		}
+		h.logger.Error("failed to delete review: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to delete review", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, model.SuccessResponse{Message: "Review deleted successfully"})
+}
+
+// GetList godoc
+// @Summary Get movie reviews
+// @Description Retrieves a paginated list of reviews for a movie, newest first
+// @Tags reviews
+// @Produce json
+// @Param id path int true "Movie ID"
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page (default is 10, max 100)"
+// @Success 200 {object} model.ReviewList
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/movies/{id}/reviews [get]
+func (h *ReviewHandler) GetList(c *gin.Context) {
+	movieID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Error("invalid movie id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid movie ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	var req model.GetListFilter
+	req.Page = parseInt(c.Query("page"), querybuilder.DefaultPage)
+	req.Limit = parseInt(c.Query("limit"), querybuilder.DefaultLimit)
+	if req.Page < 1 || req.Limit < 1 {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "page and limit must be positive integers", Code: "BAD_REQUEST"})
+		return
+	}
+	req.Limit = min(req.Limit, querybuilder.MaxLimit)
+
+	list, err := h.usecase.ReviewRepo.GetList(c.Request.Context(), movieID, req)
+	if err != nil {
+		h.logger.Error("failed to get review list: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch review list", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// target resolves the movie from the path and the reviewing user.
+func (h *ReviewHandler) target(c *gin.Context) (movieID, userID int, ok bool) {
+	movieID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		h.logger.Error("invalid movie id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid movie ID", Code: "BAD_REQUEST"})
+		return 0, 0, false
+	}
+	return movieID, currentUserID(c), true
+}
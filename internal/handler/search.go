@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/internal/rbac"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/logger"
+)
+
+type SearchHandler struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+	auth    *AuthMiddleware
+}
+
+func NewSearchHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware) *SearchHandler {
+	return &SearchHandler{
+		usecase: usecase,
+		logger:  logger,
+		cfg:     cfg,
+		auth:    auth,
+	}
+}
+
+func (h *SearchHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/v1/search", h.auth.Optional(rbac.MoviesRead), h.Search)
+	r.GET("/v1/autocomplete", h.auth.Optional(rbac.MoviesRead), h.Autocomplete)
+}
+
+// Search godoc
+// @Summary Search movies and actors
+// @Description Full-text search over movie titles, plots, directors and cast names, and over actor names.
+// @Description Supports web search syntax: "quoted phrases", OR, and -excluded words.
+// @Description Results of both types are ranked together; title matches rank above director, cast and plot matches.
+// @Description highlight and snippet wrap matched terms in <mark> tags and are not HTML-escaped.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search terms"
+// @Param type query string false "Comma separated result types: movie, actor (default both)"
+// @Param page query int false "Page number (default is 1)"
+// @Param limit query int false "Items per page (default is 10, max 100)"
+// @Success 200 {object} model.SearchResults
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Router /v1/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	var ok bool
+	req := model.SearchRequest{
+		Query: strings.TrimSpace(c.Query("q")),
+		Page:  parseInt(c.Query("page"), querybuilder.DefaultPage),
+		Limit: parseInt(c.Query("limit"), querybuilder.DefaultLimit),
+	}
+	if req.Query == "" {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "q is required", Code: "BAD_REQUEST"})
+		return
+	}
+	if req.Page < 1 || req.Limit < 1 {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "page and limit must be positive integers", Code: "BAD_REQUEST"})
+		return
+	}
+	req.Limit = min(req.Limit, querybuilder.MaxLimit)
+
+	if req.Types, ok = searchTypes(c, c.Query("type")); !ok {
+		return
+	}
+
+	res, err := h.usecase.SearchRepo.Search(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error("failed to search: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to search", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+// Autocomplete godoc
+// @Summary Suggest movie titles and actor names
+// @Description Type-ahead suggestions. Matches names starting with the input first, then names containing a word similar to it, so small typos are tolerated from three characters on.
+// @Description Matching ignores case and accents ("amelie" finds "Amélie").
+// @Tags search
+// @Produce json
+// @Param q query string true "Text typed so far"
+// @Param types query string false "Comma separated suggestion types: movie, actor (default both)"
+// @Param limit query int false "Number of suggestions (default is 8, max 20)"
+// @Success 200 {object} model.Suggestions
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Failure 503 {object} model.ErrorResponse
+// @Router /v1/autocomplete [get]
+func (h *SearchHandler) Autocomplete(c *gin.Context) {
+	var ok bool
+	req := model.AutocompleteRequest{
+		Query: strings.TrimSpace(c.Query("q")),
+		Limit: parseInt(c.Query("limit"), defaultSuggestions),
+	}
+	if req.Query == "" {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "q is required", Code: "BAD_REQUEST"})
+		return
+	}
+	if req.Limit < 1 {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "limit must be a positive integer", Code: "BAD_REQUEST"})
+		return
+	}
+	req.Limit = min(req.Limit, maxSuggestions)
+
+	if req.Types, ok = searchTypes(c, c.Query("types")); !ok {
+		return
+	}
+
+	res, err := h.usecase.SearchRepo.Autocomplete(c.Request.Context(), req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			c.JSON(http.StatusServiceUnavailable, model.ErrorResponse{Message: "Suggestions took too long", Code: "TIMEOUT"})
+			return
+		}
+		h.logger.Error("failed to autocomplete: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch suggestions", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
+
+const (
+	defaultSuggestions = 8
+	maxSuggestions     = 20
+)
+
+// searchTypes parses a comma separated list of result types. API keys
+// without actors:read are limited to movies. It writes the error response
+// itself and reports false on failure.
+func searchTypes(c *gin.Context, raw string) ([]string, bool) {
+	var types []string
+	if raw != "" {
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if t != model.SearchTypeMovie && t != model.SearchTypeActor {
+				c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "result type must be movie or actor", Code: "BAD_REQUEST"})
+				return nil, false
+			}
+			types = append(types, t)
+		}
+	}
+
+	if _, isKey := c.Get(ctxScopesKey); isKey && !allowed(c, rbac.ActorsRead) {
+		if slices.Contains(types, model.SearchTypeActor) {
+			c.JSON(http.StatusForbidden, model.ErrorResponse{Message: "Insufficient permissions", Code: "FORBIDDEN"})
+			return nil, false
+		}
+		types = []string{model.SearchTypeMovie}
+	}
+	return types, true
+}
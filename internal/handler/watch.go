@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/internal/rbac"
+	"github.com/movie-app/internal/usecase"
+	"github.com/movie-app/pkg/logger"
+)
+
+type WatchHandler struct {
+	usecase *usecase.UseCase
+	cfg     *config.Config
+	logger  *logger.Logger
+	auth    *AuthMiddleware
+}
+
+func NewWatchHandler(usecase *usecase.UseCase, cfg *config.Config, logger *logger.Logger, auth *AuthMiddleware) *WatchHandler {
+	return &WatchHandler{
+		usecase: usecase,
+		logger:  logger,
+		cfg:     cfg,
+		auth:    auth,
+	}
+}
+
+func (h *WatchHandler) RegisterRoutes(r *gin.Engine) {
+	watchHandler := r.Group("/v1/me", h.auth.AuthorizeUser(rbac.HistoryWrite))
+	{
+		watchHandler.PUT("/progress/:movie_id", h.ReportProgress)
+		watchHandler.GET("/continue-watching", h.ContinueWatching)
+		watchHandler.GET("/history", h.GetHistory)
+	}
+}
+
+// ReportProgress godoc
+// @Summary Report playback progress
+// @Description Records how far the caller has watched a movie. By default reports are buffered in the serving process and written in batches, so they show up in history and continue-watching after a few seconds, and a report accepted shortly before a crash or redeploy can be lost. Deployments with PROGRESS_WRITE_THROUGH enabled write each report before answering.
+// @Tags history
+// @Accept json
+// @Produce json
+// @Param movie_id path int true "Movie ID"
+// @Param progress body model.WatchProgressRequest true "Playback progress"
+// @Success 202 {object} model.SuccessResponse
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/progress/{movie_id} [put]
+func (h *WatchHandler) ReportProgress(c *gin.Context) {
+	movieID, err := strconv.Atoi(c.Param("movie_id"))
+	if err != nil || movieID <= 0 {
+		h.logger.Error("invalid movie id: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid movie ID", Code: "BAD_REQUEST"})
+		return
+	}
+
+	var req model.WatchProgressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("invalid progress payload: %v", err)
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "Invalid payload", Code: "BAD_REQUEST"})
+		return
+	}
+
+	err = h.usecase.WatchProgressRepo.Record(c.Request.Context(), model.WatchProgress{
+		UserID:          currentUserID(c),
+		MovieID:         movieID,
+		PositionSeconds: req.PositionSeconds,
+		DurationSeconds: req.DurationSeconds,
+		Completed:       req.Completed,
+		ReportedAt:      time.Now(),
+	})
+	if err != nil {
+		h.logger.Error("failed to record watch progress: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to record progress", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, model.SuccessResponse{Message: "Progress recorded"})
+}
+
+// ContinueWatching godoc
+// @Summary Continue watching
+// @Description Returns movies the caller started but has not finished, most recently watched first
+// @Tags history
+// @Produce json
+// @Param limit query int false "Number of movies (default 10)"
+// @Success 200 {array} model.WatchEntry
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/continue-watching [get]
+func (h *WatchHandler) ContinueWatching(c *gin.Context) {
+	limit := parseInt(c.DefaultQuery("limit", "10"), 10)
+	if limit <= 0 || limit > 100 {
+		limit = 10
+	}
+
+	entries, err := h.usecase.WatchProgressRepo.GetInProgress(c.Request.Context(), currentUserID(c), limit)
+	if err != nil {
+		h.logger.Error("failed to get in-progress movies: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch continue watching", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// GetHistory godoc
+// @Summary Get watch history
+// @Description Retrieves a paginated list of movies the caller has watched, most recent first
+// @Tags history
+// @Produce json
+// @Param page query int false "Page number"
+// @Param limit query int false "Items per page (default is 10, max 100)"
+// @Success 200 {object} model.WatchHistory
+// @Failure 400 {object} model.ErrorResponse
+// @Failure 401 {object} model.ErrorResponse
+// @Failure 403 {object} model.ErrorResponse
+// @Failure 500 {object} model.ErrorResponse
+// @Security BearerAuth
+// @Router /v1/me/history [get]
+func (h *WatchHandler) GetHistory(c *gin.Context) {
+	var req model.GetListFilter
+	req.Page = parseInt(c.Query("page"), querybuilder.DefaultPage)
+	req.Limit = parseInt(c.Query("limit"), querybuilder.DefaultLimit)
+	if req.Page < 1 || req.Limit < 1 {
+		c.JSON(http.StatusBadRequest, model.ErrorResponse{Message: "page and limit must be positive integers", Code: "BAD_REQUEST"})
+		return
+	}
+	req.Limit = min(req.Limit, querybuilder.MaxLimit)
+
+	history, err := h.usecase.WatchProgressRepo.GetHistory(c.Request.Context(), currentUserID(c), req)
+	if err != nil {
+		h.logger.Error("failed to get watch history: %v", err)
+		c.JSON(http.StatusInternalServerError, model.ErrorResponse{Message: "Failed to fetch watch history", Code: "INTERNAL_ERROR"})
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
@@ -7,13 +7,24 @@ type Actor struct {
 	FirstName string    `json:"first_name" gorm:"size:32;not null"`
 	LastName  string    `json:"last_name" gorm:"size:32;not null"`
 	Role      string    `json:"role" gorm:"size:32;default:'actor';not null"`
+	Version   int       `json:"version" gorm:"not null;default:1"`
 	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
+// ActorDocument is the representation of an actor that PATCH requests edit.
+type ActorDocument struct {
+	FirstName string `json:"first_name" binding:"required,max=32"`
+	LastName  string `json:"last_name" binding:"required,max=32"`
+	Role      string `json:"role" binding:"required,max=32"`
+}
+
 type ActorList struct {
 	Actors []Actor `json:"actors"`
-	Total  int64   `json:"total"`
+	// Total is omitted when the request asked to skip counting.
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 type ActorID struct {
@@ -21,6 +32,15 @@ type ActorID struct {
 }
 
 type MovieActor struct {
-	MovieID int `gorm:"column:movie_id"`
-	ActorID int `gorm:"column:actor_id"`
+	MovieID       int    `gorm:"column:movie_id"`
+	ActorID       int    `gorm:"column:actor_id"`
+	CharacterName string `gorm:"column:character_name"`
+	BillingOrder  int    `gorm:"column:billing_order"`
+}
+
+// CastMember is an actor as credited on a movie.
+type CastMember struct {
+	Actor
+	CharacterName string `json:"character_name"`
+	BillingOrder  int    `json:"billing_order"`
 }
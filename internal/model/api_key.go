@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type APIKey struct {
+	ID         int            `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name       string         `json:"name" gorm:"size:64;not null"`
+	Prefix     string         `json:"prefix" gorm:"size:16;not null"`
+	KeyHash    string         `json:"-" gorm:"size:64;not null;uniqueIndex"`
+	Scopes     pq.StringArray `json:"scopes" gorm:"type:text[];not null" swaggertype:"array,string"`
+	CreatedBy  *int           `json:"created_by"`
+	LastUsedAt *time.Time     `json:"last_used_at"`
+	RevokedAt  *time.Time     `json:"revoked_at"`
+	CreatedAt  time.Time      `json:"created_at" gorm:"autoCreateTime"`
+}
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required,max=64"`
+	Scopes []string `json:"scopes" binding:"required,min=1"`
+}
+
+// CreatedAPIKey carries the plaintext key. It is only returned once, at
+// creation time.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
@@ -12,15 +12,22 @@ type OrderBy struct {
 
 type Filter struct {
 	Column string `json:"column"`
-	Type   string `json:"type"` // eq, ne, gt, gte, lt, lte, search
+	Type   string `json:"type"` // eq, ne, gt, gte, lt, lte, search, in, between, is_null, all
 	Value  string `json:"value"`
 }
 
 type GetListFilter struct {
-	Page    int       `json:"offset"`
+	Page    int       `json:"page"`
 	Limit   int       `json:"limit"`
 	Filters []Filter  `json:"filters"`
 	OrderBy []OrderBy `json:"order_by"`
+	// After and Before are opaque cursors from a previous page. When one is
+	// set Page is ignored.
+	After     string `json:"after"`
+	Before    string `json:"before"`
+	SkipCount bool   `json:"skip_count"`
+	// Facets names the bucket counts to compute over the filtered set.
+	Facets []string `json:"facets"`
 }
 
 type UpdateFieldItem struct {
@@ -31,10 +38,14 @@ type UpdateFieldItem struct {
 type UpdateFieldRequest struct {
 	Filter []Filter          `json:"filter"`
 	Items  []UpdateFieldItem `json:"items"`
+	// DryRun reports the rows the update would change without writing.
+	DryRun bool `json:"dry_run"`
 }
 
 type RowsEffected struct {
-	RowsEffected int `json:"rows_effected"`
+	RowsEffected int   `json:"rows_effected"`
+	IDs          []int `json:"ids"`
+	DryRun       bool  `json:"dry_run"`
 }
 
 type ErrorResponse struct {
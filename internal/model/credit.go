@@ -0,0 +1,70 @@
+package model
+
+const (
+	DepartmentDirecting      = "directing"
+	DepartmentWriting        = "writing"
+	DepartmentProducing      = "producing"
+	DepartmentMusic          = "music"
+	DepartmentCinematography = "cinematography"
+)
+
+// DefaultJobs is the job title used when a crew credit is created without one.
+var DefaultJobs = map[string]string{
+	DepartmentDirecting:      "Director",
+	DepartmentWriting:        "Writer",
+	DepartmentProducing:      "Producer",
+	DepartmentMusic:          "Original Music Composer",
+	DepartmentCinematography: "Director of Photography",
+}
+
+// Credit links a person (a row of the actors table) to a movie as crew.
+type Credit struct {
+	ID         int    `gorm:"primaryKey;autoIncrement"`
+	MovieID    int    `gorm:"column:movie_id"`
+	PersonID   int    `gorm:"column:person_id"`
+	Department string `gorm:"size:32;not null"`
+	Job        string `gorm:"size:64;not null"`
+}
+
+// CrewMember is a person as credited on a movie's crew.
+type CrewMember struct {
+	Actor
+	Department string `json:"department"`
+	Job        string `json:"job"`
+}
+
+type CrewRequest struct {
+	Id         int    `json:"id" binding:"required"`
+	Department string `json:"department" binding:"required,oneof=directing writing producing music cinematography"`
+	Job        string `json:"job" binding:"max=64"`
+}
+
+type MovieCredits struct {
+	MovieID int          `json:"movie_id"`
+	Cast    []CastMember `json:"cast"`
+	Crew    []CrewMember `json:"crew"`
+}
+
+type MovieSummary struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Year  int    `json:"year"`
+}
+
+type CastCredit struct {
+	Movie         MovieSummary `json:"movie" gorm:"embedded;embeddedPrefix:movie_"`
+	CharacterName string       `json:"character_name"`
+	BillingOrder  int          `json:"billing_order"`
+}
+
+type CrewCredit struct {
+	Movie      MovieSummary `json:"movie" gorm:"embedded;embeddedPrefix:movie_"`
+	Department string       `json:"department"`
+	Job        string       `json:"job"`
+}
+
+type Filmography struct {
+	Person Actor        `json:"person"`
+	Cast   []CastCredit `json:"cast"`
+	Crew   []CrewCredit `json:"crew"`
+}
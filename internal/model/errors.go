@@ -0,0 +1,20 @@
+package model
+
+import "errors"
+
+var (
+	ErrNotFound         = errors.New("record not found")
+	ErrUnknownReference = errors.New("referenced record does not exist")
+	ErrAlreadyExists    = errors.New("record already exists")
+	ErrEmailTaken       = errors.New("email is already registered")
+	ErrDefaultList      = errors.New("the default list cannot be deleted")
+	ErrInvalidOrder     = errors.New("order must contain every list item exactly once")
+	ErrInvalidFilter    = errors.New("invalid filter")
+	ErrTooManyRows      = errors.New("too many rows matched")
+	ErrInvalidPatch     = errors.New("patch result is invalid")
+	ErrVersionMismatch  = errors.New("resource was modified since it was read")
+
+	ErrTokenExpired = errors.New("token has expired")
+	ErrTokenRevoked = errors.New("token has been revoked")
+	ErrTokenReused  = errors.New("refresh token reuse detected")
+)
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+type Genre struct {
+	ID        int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name      string    `json:"name" gorm:"size:64;uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type GenreList struct {
+	Genres []Genre `json:"genres"`
+	Total  int64   `json:"total"`
+}
+
+type GenreRequest struct {
+	Name string `json:"name" binding:"required,max=64"`
+}
+
+type MovieGenre struct {
+	MovieID int `gorm:"column:movie_id"`
+	GenreID int `gorm:"column:genre_id"`
+}
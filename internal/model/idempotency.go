@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// IdempotencyKey is the stored outcome of a request sent with an
+// Idempotency-Key header. Owner identifies the caller, so two callers may use
+// the same key independently. StatusCode is nil while the first request is
+// still running.
+type IdempotencyKey struct {
+	Owner       string `gorm:"primaryKey;size:64"`
+	Key         string `gorm:"primaryKey;size:255"`
+	Fingerprint string `gorm:"size:64;not null"`
+	StatusCode  *int
+	ContentType string
+	Response    []byte
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	ExpiresAt   time.Time `gorm:"not null"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import "time"
+
+const (
+	ListVisibilityPrivate = "private"
+	ListVisibilityPublic  = "public"
+
+	DefaultListName = "Watchlist"
+)
+
+type List struct {
+	ID         int         `json:"id" gorm:"primaryKey;autoIncrement"`
+	UserID     int         `json:"user_id" gorm:"not null"`
+	Name       string      `json:"name" gorm:"type:varchar(128);not null"`
+	Slug       string      `json:"slug" gorm:"type:varchar(64);unique;not null"`
+	Visibility string      `json:"visibility" gorm:"type:varchar(16);not null"`
+	IsDefault  bool        `json:"is_default"`
+	ItemCount  int         `json:"item_count" gorm:"->"`
+	Items      []ListEntry `json:"items,omitempty" gorm:"-"`
+	CreatedAt  time.Time   `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time   `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type ListItem struct {
+	ListID   int       `gorm:"primaryKey"`
+	MovieID  int       `gorm:"primaryKey"`
+	Position int       `gorm:"not null"`
+	AddedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type ListEntry struct {
+	Position int          `json:"position"`
+	AddedAt  time.Time    `json:"added_at"`
+	Movie    MovieSummary `json:"movie" gorm:"embedded;embeddedPrefix:movie_"`
+}
+
+type ListRequest struct {
+	Name       string `json:"name" binding:"required,max=128"`
+	Visibility string `json:"visibility" binding:"omitempty,oneof=private public"`
+}
+
+type ListItemRequest struct {
+	MovieID int `json:"movie_id" binding:"required"`
+	// Position is 1-based; zero appends to the end of the list.
+	Position int `json:"position" binding:"min=0"`
+}
+
+type ReorderListRequest struct {
+	MovieIDs []int `json:"movie_ids" binding:"required"`
+}
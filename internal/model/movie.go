@@ -3,37 +3,90 @@ package model
 import "time"
 
 type Movie struct {
-	ID        int       `json:"id" gorm:"primaryKey;autoIncrement"`
-	Title     string    `json:"title" gorm:"size:255;not null"`
-	Director  string    `json:"director" gorm:"size:255;not null"`
-	Year      int       `json:"year" gorm:"not null"`
-	Plot      string    `json:"plot" gorm:"type:text"`
-	Cast      []Actor   `json:"cast" gorm:"many2many:movie_actors"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID            int          `json:"id" gorm:"primaryKey;autoIncrement"`
+	Title         string       `json:"title" gorm:"size:255;not null"`
+	Year          int          `json:"year" gorm:"not null"`
+	Plot          string       `json:"plot" gorm:"type:text"`
+	Cast          []CastMember `json:"cast" gorm:"-"`
+	Crew          []CrewMember `json:"crew" gorm:"-"`
+	Genres        []Genre      `json:"genres" gorm:"many2many:movie_genres"`
+	AverageRating float64      `json:"average_rating" gorm:"->"`
+	RatingCount   int          `json:"rating_count" gorm:"->"`
+	Version       int          `json:"version" gorm:"not null;default:1"`
+	CreatedAt     time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 type MovieList struct {
 	Movies []Movie `json:"movies"`
-	Count  int     `json:"count"`
+	// Total is omitted when the request asked to skip counting.
+	Total      *int64 `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+	// Facets holds the requested bucket counts, keyed by facet name.
+	Facets map[string][]FacetBucket `json:"facets,omitempty"`
+}
+
+const (
+	FacetGenre    = "genre"
+	FacetDecade   = "decade"
+	FacetDirector = "director"
+)
+
+// FacetBucket counts the movies sharing one facet value. Value is accepted
+// by the matching list filter: genre=<id>, year[between]=<value> for
+// decades and director=<name>.
+type FacetBucket struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+	Count int64  `json:"count"`
+}
+
+// CastRequest credits an actor on a movie. BillingOrder defaults to the
+// position in the casts array when omitted.
+type CastRequest struct {
+	Id            int    `json:"id" binding:"required"`
+	CharacterName string `json:"character_name" binding:"max=128"`
+	BillingOrder  int    `json:"billing_order" binding:"min=0"`
 }
 
 type CreateMovieRequest struct {
-	Title    string `json:"title" binding:"required"`
-	Director string `json:"director" binding:"required"`
-	Year     int    `json:"year" binding:"required"`
-	Plot     string `json:"plot" binding:"required"`
-	Casts    []struct {
+	Title  string        `json:"title" binding:"required"`
+	Year   int           `json:"year" binding:"required"`
+	Plot   string        `json:"plot" binding:"required"`
+	Casts  []CastRequest `json:"casts" binding:"required,dive,required"`
+	Crew   []CrewRequest `json:"crew" binding:"dive"`
+	Genres []struct {
 		Id int `json:"id" binding:"required"`
-	} `json:"casts" binding:"required,dive,required"`
+	} `json:"genres" binding:"dive,required"`
 }
 
 type UpdateMovieRequest struct {
-	Title    string `json:"title" binding:"required"`
-	Director string `json:"director" binding:"required"`
-	Year     int    `json:"year" binding:"required"`
-	Plot     string `json:"plot" binding:"required"`
-	Casts    []struct {
+	Title  string        `json:"title" binding:"required"`
+	Year   int           `json:"year" binding:"required"`
+	Plot   string        `json:"plot" binding:"required"`
+	Casts  []CastRequest `json:"casts" binding:"required,dive,required"`
+	Crew   []CrewRequest `json:"crew" binding:"dive"`
+	Genres []struct {
 		Id int `json:"id" binding:"required"`
-	} `json:"casts" binding:"required,dive,required"`
+	} `json:"genres" binding:"dive,required"`
+}
+
+// MovieDocument is the representation of a movie that PATCH requests edit.
+// Cast is keyed by actor ID so a merge patch can add, change or remove a
+// single member; crew and genres are replaced as a whole.
+type MovieDocument struct {
+	Title  string            `json:"title" binding:"required"`
+	Year   int               `json:"year" binding:"required"`
+	Plot   string            `json:"plot"`
+	Cast   map[int]CastEntry `json:"cast" binding:"dive"`
+	Crew   []CrewRequest     `json:"crew" binding:"dive"`
+	Genres []int             `json:"genres"`
+}
+
+// CastEntry is a cast member in a MovieDocument. A zero BillingOrder on a
+// new member places it after the existing cast.
+type CastEntry struct {
+	CharacterName string `json:"character_name" binding:"max=128"`
+	BillingOrder  int    `json:"billing_order" binding:"min=0"`
 }
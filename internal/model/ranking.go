@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+type TopRatedFilter struct {
+	Page     int
+	Limit    int
+	MinVotes int
+	Genre    string
+	YearFrom int
+	YearTo   int
+}
+
+type TopRatedMovie struct {
+	Rank           int     `json:"rank" gorm:"-"`
+	ID             int     `json:"id"`
+	Title          string  `json:"title"`
+	Year           int     `json:"year"`
+	AverageRating  float64 `json:"average_rating"`
+	RatingCount    int     `json:"rating_count"`
+	WeightedRating float64 `json:"weighted_rating"`
+}
+
+type TopRatedList struct {
+	Movies      []TopRatedMovie `json:"movies"`
+	Total       int64           `json:"total"`
+	MinVotes    int             `json:"min_votes"`
+	MeanRating  float64         `json:"mean_rating"`
+	RefreshedAt *time.Time      `json:"refreshed_at"`
+}
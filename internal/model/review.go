@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+type Review struct {
+	ID        int       `json:"id" gorm:"primaryKey;autoIncrement"`
+	MovieID   int       `json:"movie_id" gorm:"not null"`
+	UserID    int       `json:"user_id" gorm:"not null"`
+	Rating    int       `json:"rating" gorm:"not null"`
+	Body      string    `json:"body" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type ReviewList struct {
+	Reviews []Review `json:"reviews"`
+	Total   int64    `json:"total"`
+}
+
+type ReviewRequest struct {
+	Rating int    `json:"rating" binding:"required,min=1,max=10"`
+	Body   string `json:"body" binding:"max=5000"`
+}
@@ -0,0 +1,49 @@
+package model
+
+const (
+	SearchTypeMovie = "movie"
+	SearchTypeActor = "actor"
+)
+
+type SearchRequest struct {
+	Query string
+	Types []string
+	Page  int
+	Limit int
+}
+
+// SearchResult is one hit of a mixed search. Highlight and Snippet contain
+// the matched terms wrapped in <mark> tags; the surrounding text is not
+// escaped.
+type SearchResult struct {
+	Type      string  `json:"type"`
+	ID        int     `json:"id"`
+	Name      string  `json:"name"`
+	Year      *int    `json:"year,omitempty"`
+	Rank      float64 `json:"rank"`
+	Highlight string  `json:"highlight"`
+	Snippet   string  `json:"snippet,omitempty"`
+}
+
+type SearchResults struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+	Total   int64          `json:"total"`
+}
+
+type AutocompleteRequest struct {
+	Query string
+	Types []string
+	Limit int
+}
+
+type Suggestion struct {
+	Type  string `json:"type"`
+	ID    int    `json:"id"`
+	Label string `json:"label"`
+	Year  *int   `json:"year,omitempty"`
+}
+
+type Suggestions struct {
+	Suggestions []Suggestion `json:"suggestions"`
+}
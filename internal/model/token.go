@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+type RefreshToken struct {
+	ID         int       `gorm:"primaryKey;autoIncrement"`
+	UserID     int       `gorm:"not null"`
+	FamilyID   string    `gorm:"size:32;not null"`
+	TokenHash  string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+	ReplacedBy *int
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
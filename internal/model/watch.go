@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// WatchProgress is a playback report from a client.
+type WatchProgress struct {
+	UserID          int
+	MovieID         int
+	PositionSeconds int
+	DurationSeconds int
+	Completed       bool
+	ReportedAt      time.Time
+}
+
+// WatchEntry is the stored progress of a user on a movie.
+type WatchEntry struct {
+	Movie           MovieSummary `json:"movie" gorm:"embedded;embeddedPrefix:movie_"`
+	PositionSeconds int          `json:"position_seconds"`
+	DurationSeconds int          `json:"duration_seconds"`
+	Completed       bool         `json:"completed"`
+	StartedAt       time.Time    `json:"started_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	CompletedAt     *time.Time   `json:"completed_at"`
+}
+
+type WatchHistory struct {
+	Entries []WatchEntry `json:"entries"`
+	Total   int64        `json:"total"`
+}
+
+// WatchProgressRequest is bounded to fit the INT columns it is stored in; one
+// out-of-range report would otherwise fail the whole batch it is flushed with.
+type WatchProgressRequest struct {
+	PositionSeconds int  `json:"position_seconds" binding:"min=0,max=2147483647"`
+	DurationSeconds int  `json:"duration_seconds" binding:"min=0,max=2147483647"`
+	Completed       bool `json:"completed"`
+}
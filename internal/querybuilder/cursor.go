@@ -0,0 +1,243 @@
+package querybuilder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/movie-app/internal/model"
+	"gorm.io/gorm"
+)
+
+// idField is the unique field every schema used with Paginate must define.
+// It is appended to the sort keys so rows never tie.
+const idField = "id"
+
+// cursor is the decoded form of the opaque after/before tokens. It records
+// the sort the token was issued for and the sort key values of the row it
+// points at, id last.
+type cursor struct {
+	Sort   string `json:"s"`
+	Values []any  `json:"v"`
+}
+
+// Page describes how a list query was paginated, for building the cursors
+// of the response.
+type Page struct {
+	orders   []model.OrderBy
+	backward bool
+	limit    int
+	cursor   bool
+	offset   int
+}
+
+// Paginate orders db by req.OrderBy plus id and limits it to one page. With
+// req.After or req.Before set it seeks past the cursor row (keyset
+// pagination); otherwise it skips (page-1)*limit rows. One extra row is
+// fetched so Cursors can tell whether another page exists.
+func (s Schema) Paginate(db *gorm.DB, req model.GetListFilter) (*gorm.DB, Page, error) {
+	if _, ok := s[idField]; !ok {
+		return nil, Page{}, fmt.Errorf("querybuilder: schema has no %q field", idField)
+	}
+
+	orders := make([]model.OrderBy, len(req.OrderBy))
+	for i, o := range req.OrderBy {
+		orders[i] = model.OrderBy{Column: o.Column, Order: strings.ToLower(o.Order)}
+	}
+	if !slices.ContainsFunc(orders, func(o model.OrderBy) bool { return o.Column == idField }) {
+		orders = append(orders, model.OrderBy{Column: idField, Order: "asc"})
+	}
+
+	page := Page{orders: orders, limit: req.Limit, backward: req.Before != ""}
+	if page.limit <= 0 {
+		page.limit = DefaultLimit
+	}
+
+	sortOrders := orders
+	if page.backward {
+		sortOrders = reversed(orders)
+	}
+	db, err := s.Order(db, sortOrders)
+	if err != nil {
+		return nil, Page{}, err
+	}
+
+	token := req.After
+	if page.backward {
+		if req.After != "" {
+			return nil, Page{}, invalid("after and before cannot be combined")
+		}
+		token = req.Before
+	}
+
+	if token != "" {
+		page.cursor = true
+		values, err := s.decodeCursor(token, orders)
+		if err != nil {
+			return nil, Page{}, err
+		}
+		db = s.seek(db, orders, values, page.backward)
+	} else if req.Page > 1 {
+		page.offset = (req.Page - 1) * page.limit
+		db = db.Offset(page.offset)
+	}
+
+	return db.Limit(page.limit + 1), page, nil
+}
+
+// Cursors trims the extra row fetched by Paginate, restores the requested
+// order for backward pages and returns the cursors of the neighbouring
+// pages. A cursor is empty when there is no such page.
+func Cursors[T any](s Schema, db *gorm.DB, rows []T, page Page) ([]T, string, string, error) {
+	more := len(rows) > page.limit
+	if more {
+		rows = rows[:page.limit]
+	}
+	if page.backward {
+		slices.Reverse(rows)
+	}
+	if len(rows) == 0 {
+		return rows, "", "", nil
+	}
+
+	var hasNext, hasPrev bool
+	if page.backward {
+		hasNext, hasPrev = true, more
+	} else {
+		hasNext, hasPrev = more, page.cursor || page.offset > 0
+	}
+
+	var next, prev string
+	var err error
+	if hasNext {
+		if next, err = s.encodeCursor(db, &rows[len(rows)-1], page.orders); err != nil {
+			return nil, "", "", err
+		}
+	}
+	if hasPrev {
+		if prev, err = s.encodeCursor(db, &rows[0], page.orders); err != nil {
+			return nil, "", "", err
+		}
+	}
+	return rows, next, prev, nil
+}
+
+// seek keeps the rows strictly after (or before) the cursor row in the sort
+// order, expanded as
+//
+//	a > ? OR (a = ? AND b > ?) OR (a = ? AND b = ? AND id > ?)
+//
+// with the comparison flipped for descending keys and backward pages.
+func (s Schema) seek(db *gorm.DB, orders []model.OrderBy, values []any, backward bool) *gorm.DB {
+	var (
+		ors  []string
+		args []any
+	)
+	for i, o := range orders {
+		var and []string
+		for j := 0; j < i; j++ {
+			and = append(and, s[orders[j].Column].Column+" = ?")
+			args = append(args, values[j])
+		}
+
+		op := ">"
+		if (o.Order == "desc") != backward {
+			op = "<"
+		}
+		and = append(and, s[o.Column].Column+" "+op+" ?")
+		args = append(args, values[i])
+
+		ors = append(ors, "("+strings.Join(and, " AND ")+")")
+	}
+	return db.Where("("+strings.Join(ors, " OR ")+")", args...)
+}
+
+func (s Schema) encodeCursor(db *gorm.DB, row any, orders []model.OrderBy) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(row); err != nil {
+		return "", err
+	}
+
+	rv := reflect.ValueOf(row)
+	c := cursor{Sort: sortSpec(orders), Values: make([]any, len(orders))}
+	for i, o := range orders {
+		field := stmt.Schema.LookUpField(bareColumn(s[o.Column].Column))
+		if field == nil {
+			return "", fmt.Errorf("querybuilder: no struct field for sort key %q", o.Column)
+		}
+		c.Values[i], _ = field.ValueOf(db.Statement.Context, rv)
+	}
+
+	buf, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (s Schema) decodeCursor(token string, orders []model.OrderBy) ([]any, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid("malformed cursor")
+	}
+
+	var c cursor
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	if err := dec.Decode(&c); err != nil {
+		return nil, invalid("malformed cursor")
+	}
+	if c.Sort != sortSpec(orders) {
+		return nil, invalid("cursor was issued for a different sort")
+	}
+	if len(c.Values) != len(orders) {
+		return nil, invalid("malformed cursor")
+	}
+
+	values := make([]any, len(orders))
+	for i, o := range orders {
+		field, ok := s[o.Column]
+		if !ok {
+			return nil, invalid("malformed cursor")
+		}
+		if values[i], err = field.Type.parse(fmt.Sprint(c.Values[i])); err != nil {
+			return nil, invalid("malformed cursor")
+		}
+	}
+	return values, nil
+}
+
+func sortSpec(orders []model.OrderBy) string {
+	keys := make([]string, len(orders))
+	for i, o := range orders {
+		keys[i] = o.Column
+		if o.Order == "desc" {
+			keys[i] = "-" + o.Column
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+func reversed(orders []model.OrderBy) []model.OrderBy {
+	out := make([]model.OrderBy, len(orders))
+	for i, o := range orders {
+		if o.Order == "desc" {
+			o.Order = "asc"
+		} else {
+			o.Order = "desc"
+		}
+		out[i] = o
+	}
+	return out
+}
+
+func bareColumn(column string) string {
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		return column[i+1:]
+	}
+	return column
+}
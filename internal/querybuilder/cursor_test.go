@@ -0,0 +1,250 @@
+package querybuilder
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/movie-app/internal/model"
+)
+
+func paginate(t *testing.T, req model.GetListFilter) (string, []any, Page) {
+	t.Helper()
+	db, page, err := itemFields.Paginate(dryRun(t), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sql, args := build(t, db)
+	return sql, args, page
+}
+
+// items returns n rows with ids from first, two per year, so sorting by
+// year alone would tie.
+func items(first, n int) []item {
+	rows := make([]item, n)
+	for i := range rows {
+		id := first + i
+		rows[i] = item{ID: id, Year: 2000 - id/2}
+	}
+	return rows
+}
+
+func ids(rows []item) []int {
+	out := make([]int, len(rows))
+	for i, row := range rows {
+		out[i] = row.ID
+	}
+	return out
+}
+
+func TestPaginateOffset(t *testing.T) {
+	sql, args, _ := paginate(t, model.GetListFilter{Page: 3, Limit: 10, OrderBy: []model.OrderBy{{Column: "year", Order: "desc"}}})
+	if want := "ORDER BY items.year DESC,items.id LIMIT $1 OFFSET $2"; sql != want {
+		t.Errorf("got SQL %q, want %q", sql, want)
+	}
+	if want := []any{11, 20}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+}
+
+func TestPaginateForwardAndBack(t *testing.T) {
+	req := model.GetListFilter{Page: 1, Limit: 3, OrderBy: []model.OrderBy{{Column: "year", Order: "desc"}}}
+
+	// First page: one extra row tells there is a next page, and there is
+	// no previous one.
+	_, _, page := paginate(t, req)
+	rows, next, prev, err := Cursors(itemFields, dryRun(t), items(1, 4), page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(rows); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("first page has rows %v", got)
+	}
+	if next == "" || prev != "" {
+		t.Fatalf("first page: next %q, prev %q", next, prev)
+	}
+
+	// The next page seeks past row 3 (year 1999), breaking the tie on id.
+	req.After = next
+	sql, args, page := paginate(t, req)
+	if want := "WHERE ((items.year < $1) OR (items.year = $2 AND items.id > $3)) ORDER BY items.year DESC,items.id LIMIT $4"; sql != want {
+		t.Errorf("got SQL %q, want %q", sql, want)
+	}
+	if want := []any{1999, 1999, 3, 4}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	// A short page is the last one but still has a previous page.
+	rows, next, prev, err = Cursors(itemFields, dryRun(t), items(4, 2), page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(rows); !reflect.DeepEqual(got, []int{4, 5}) {
+		t.Errorf("second page has rows %v", got)
+	}
+	if next != "" || prev == "" {
+		t.Fatalf("last page: next %q, prev %q", next, prev)
+	}
+
+	// Going back from row 4 reverses every key and the comparisons.
+	req.After, req.Before = "", prev
+	sql, args, page = paginate(t, req)
+	if want := "WHERE ((items.year > $1) OR (items.year = $2 AND items.id < $3)) ORDER BY items.year,items.id DESC LIMIT $4"; sql != want {
+		t.Errorf("got SQL %q, want %q", sql, want)
+	}
+	if want := []any{1998, 1998, 4, 4}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %v, want %v", args, want)
+	}
+
+	// The database returns the backward page nearest row first; Cursors
+	// restores the requested order. Without an extra row this is the
+	// first page, so only next is set.
+	first := items(1, 3)
+	rows, next, prev, err = Cursors(itemFields, dryRun(t), []item{first[2], first[1], first[0]}, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(rows); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Errorf("backward page has rows %v", got)
+	}
+	if next == "" || prev != "" {
+		t.Errorf("backward first page: next %q, prev %q", next, prev)
+	}
+
+	// With the extra row there is an earlier page still.
+	_, _, prev, err = Cursors(itemFields, dryRun(t), []item{{ID: 3, Year: 1999}, {ID: 2, Year: 1999}, {ID: 1, Year: 2000}, {ID: 0, Year: 2000}}, page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev == "" {
+		t.Error("backward page with more rows has no prev cursor")
+	}
+}
+
+func TestPaginateMixedSort(t *testing.T) {
+	orders := []model.OrderBy{{Column: "title", Order: "asc"}, {Column: "rating", Order: "desc"}}
+	token, err := itemFields.encodeCursor(dryRun(t), &item{ID: 7, Title: "Heat", Rating: 8.25}, append(orders, model.OrderBy{Column: "id", Order: "asc"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		req      model.GetListFilter
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name:     "after",
+			req:      model.GetListFilter{Limit: 5, OrderBy: orders, After: token},
+			wantSQL:  "WHERE ((items.title > $1) OR (items.title = $2 AND items.rating < $3) OR (items.title = $4 AND items.rating = $5 AND items.id > $6)) ORDER BY items.title,items.rating DESC,items.id LIMIT $7",
+			wantArgs: []any{"Heat", "Heat", 8.25, "Heat", 8.25, 7, 6},
+		},
+		{
+			name:     "before",
+			req:      model.GetListFilter{Limit: 5, OrderBy: orders, Before: token},
+			wantSQL:  "WHERE ((items.title < $1) OR (items.title = $2 AND items.rating > $3) OR (items.title = $4 AND items.rating = $5 AND items.id < $6)) ORDER BY items.title DESC,items.rating,items.id DESC LIMIT $7",
+			wantArgs: []any{"Heat", "Heat", 8.25, "Heat", 8.25, 7, 6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, _ := paginate(t, tt.req)
+			if sql != tt.wantSQL {
+				t.Errorf("got SQL %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("got args %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestPaginateExplicitID(t *testing.T) {
+	sql, _, _ := paginate(t, model.GetListFilter{Limit: 5, OrderBy: []model.OrderBy{{Column: "id", Order: "desc"}}})
+	if want := "ORDER BY items.id DESC LIMIT $1"; sql != want {
+		t.Errorf("got SQL %q, want %q", sql, want)
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	zone := time.FixedZone("UTC+5", 5*60*60)
+	created := time.Date(2024, 2, 29, 23, 59, 58, 123456789, zone)
+
+	tests := []struct {
+		name  string
+		row   item
+		sort  string
+		wants []any
+	}{
+		{"time keeps its instant and nanoseconds", item{ID: 1, CreatedAt: created}, "created_at", []any{created, 1}},
+		{"float keeps its precision", item{ID: 2, Rating: 7.123456789012}, "rating", []any{7.123456789012, 2}},
+		{"large id", item{ID: 1<<31 - 1, Year: 1999}, "year", []any{1999, 1<<31 - 1}},
+		{"string with quotes", item{ID: 3, Title: `"Heat", 1995`}, "title", []any{`"Heat", 1995`, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := []model.OrderBy{{Column: tt.sort, Order: "desc"}, {Column: "id", Order: "asc"}}
+			token, err := itemFields.encodeCursor(dryRun(t), &tt.row, orders)
+			if err != nil {
+				t.Fatal(err)
+			}
+			values, err := itemFields.decodeCursor(token, orders)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i, want := range tt.wants {
+				if w, ok := want.(time.Time); ok {
+					if got, ok := values[i].(time.Time); !ok || !got.Equal(w) {
+						t.Errorf("value %d: got %v, want %v", i, values[i], w)
+					}
+					continue
+				}
+				if !reflect.DeepEqual(values[i], want) {
+					t.Errorf("value %d: got %#v, want %#v", i, values[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestCursorRejects(t *testing.T) {
+	byYear := []model.OrderBy{{Column: "year", Order: "desc"}, {Column: "id", Order: "asc"}}
+	token, err := itemFields.encodeCursor(dryRun(t), &item{ID: 1, Year: 1999}, byYear)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name   string
+		token  string
+		orders []model.OrderBy
+	}{
+		{"different sort", token, []model.OrderBy{{Column: "year", Order: "asc"}, {Column: "id", Order: "asc"}}},
+		{"not base64", "%%%", byYear},
+		{"not JSON", raw("year"), byYear},
+		{"wrong number of values", raw(`{"s":"-year,id","v":[1999]}`), byYear},
+		{"wrong value type", raw(`{"s":"-year,id","v":["1999 OR 1=1",1]}`), byYear},
+		{"unknown field", raw(`{"s":"-secret,id","v":[1,1]}`), []model.OrderBy{{Column: "secret", Order: "desc"}, {Column: "id", Order: "asc"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := itemFields.decodeCursor(tt.token, tt.orders); !errors.Is(err, model.ErrInvalidFilter) {
+				t.Errorf("got error %v, want %v", err, model.ErrInvalidFilter)
+			}
+		})
+	}
+}
+
+func TestPaginateRequiresID(t *testing.T) {
+	schema := Schema{"year": itemFields["year"]}
+	if _, _, err := schema.Paginate(dryRun(t), model.GetListFilter{Limit: 5}); err == nil {
+		t.Error("expected an error for a schema without an id field")
+	}
+}
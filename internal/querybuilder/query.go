@@ -0,0 +1,122 @@
+package querybuilder
+
+import (
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/movie-app/internal/model"
+)
+
+const (
+	DefaultPage  = 1
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+// listParams are the query parameters ParseQuery reads itself.
+var listParams = []string{"page", "limit", "sort", "after", "before", "count"}
+
+// QueryOptions tunes ParseQuery for one endpoint.
+type QueryOptions struct {
+	// DefaultOps is the operator used for "field=value" per field. Fields
+	// not listed default to Eq.
+	DefaultOps map[string]Operator
+	// Reserved query parameters are left to the handler instead of being
+	// read as filters.
+	Reserved []string
+}
+
+// ParseQuery reads list parameters from a query string:
+//
+//	?page=2&limit=20                 pagination, 1-based, limit capped at MaxLimit
+//	?role=actor                      filter with the field's default operator
+//	?first_name[search]=tom          filter with an explicit operator
+//	?year[between]=1990,1999         operands of in and between are comma separated
+//	?sort=-created_at,last_name      sort keys, "-" for descending
+//	?after=<cursor>, ?before=<cursor> keyset pagination from a previous page
+//	?count=false                     skip counting the total
+//
+// Field names and operators are only checked for syntax here; the entity
+// Schema validates them when the filter is applied.
+func ParseQuery(values url.Values, opts QueryOptions) (model.GetListFilter, error) {
+	req := model.GetListFilter{Page: DefaultPage, Limit: DefaultLimit}
+
+	var err error
+	if v := values.Get("page"); v != "" {
+		if req.Page, err = strconv.Atoi(v); err != nil || req.Page < 1 {
+			return model.GetListFilter{}, invalid("page must be a positive integer")
+		}
+	}
+	if v := values.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil || req.Limit < 1 {
+			return model.GetListFilter{}, invalid("limit must be a positive integer")
+		}
+		req.Limit = min(req.Limit, MaxLimit)
+	}
+
+	req.After = values.Get("after")
+	req.Before = values.Get("before")
+	if req.After != "" && req.Before != "" {
+		return model.GetListFilter{}, invalid("after and before cannot be combined")
+	}
+
+	if v := values.Get("count"); v != "" {
+		count, err := strconv.ParseBool(v)
+		if err != nil {
+			return model.GetListFilter{}, invalid("count must be true or false")
+		}
+		req.SkipCount = !count
+	}
+
+	if v := values.Get("sort"); v != "" {
+		for _, key := range strings.Split(v, ",") {
+			key = strings.TrimSpace(key)
+			order := "asc"
+			if name, ok := strings.CutPrefix(key, "-"); ok {
+				key, order = name, "desc"
+			}
+			if key == "" {
+				return model.GetListFilter{}, invalid("empty sort key in %q", v)
+			}
+			req.OrderBy = append(req.OrderBy, model.OrderBy{Column: key, Order: order})
+		}
+	}
+
+	// Map iteration order is random; sort the keys so the same URL always
+	// builds the same query.
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if slices.Contains(listParams, key) || slices.Contains(opts.Reserved, key) {
+			continue
+		}
+
+		field, op := key, ""
+		if i := strings.IndexByte(key, '['); i >= 0 {
+			if !strings.HasSuffix(key, "]") || i == 0 {
+				return model.GetListFilter{}, invalid("malformed filter %q, expected field[operator]", key)
+			}
+			field, op = key[:i], key[i+1:len(key)-1]
+			if op == "" {
+				return model.GetListFilter{}, invalid("malformed filter %q, missing operator", key)
+			}
+		} else if def, ok := opts.DefaultOps[field]; ok {
+			op = string(def)
+		} else {
+			op = string(Eq)
+		}
+
+		for _, value := range values[key] {
+			req.Filters = append(req.Filters, model.Filter{Column: field, Type: op, Value: value})
+		}
+	}
+
+	return req, nil
+}
@@ -0,0 +1,161 @@
+package querybuilder
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/movie-app/internal/model"
+)
+
+func TestParseQuery(t *testing.T) {
+	opts := QueryOptions{
+		DefaultOps: map[string]Operator{"title": Search},
+		Reserved:   []string{"q", "facets"},
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  model.GetListFilter
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			want:  model.GetListFilter{Page: DefaultPage, Limit: DefaultLimit},
+		},
+		{
+			name:  "page and limit",
+			query: "page=3&limit=25",
+			want:  model.GetListFilter{Page: 3, Limit: 25},
+		},
+		{
+			name:  "limit is capped",
+			query: "limit=1000",
+			want:  model.GetListFilter{Page: DefaultPage, Limit: MaxLimit},
+		},
+		{
+			name:  "field with the eq default",
+			query: "role=actor",
+			want: model.GetListFilter{Page: 1, Limit: 10, Filters: []model.Filter{
+				{Column: "role", Type: "eq", Value: "actor"},
+			}},
+		},
+		{
+			name:  "field with a configured default operator",
+			query: "title=heat",
+			want: model.GetListFilter{Page: 1, Limit: 10, Filters: []model.Filter{
+				{Column: "title", Type: "search", Value: "heat"},
+			}},
+		},
+		{
+			name:  "explicit operator overrides the default",
+			query: "title[eq]=Heat",
+			want: model.GetListFilter{Page: 1, Limit: 10, Filters: []model.Filter{
+				{Column: "title", Type: "eq", Value: "Heat"},
+			}},
+		},
+		{
+			name:  "in, between and is_null keep their raw operands",
+			query: "id[in]=1,2,3&year[between]=1990,1999&plot[is_null]=true",
+			want: model.GetListFilter{Page: 1, Limit: 10, Filters: []model.Filter{
+				{Column: "id", Type: "in", Value: "1,2,3"},
+				{Column: "plot", Type: "is_null", Value: "true"},
+				{Column: "year", Type: "between", Value: "1990,1999"},
+			}},
+		},
+		{
+			name:  "a field can be filtered more than once",
+			query: "year[lte]=1999&year[gte]=1990&tag=a&tag=b",
+			want: model.GetListFilter{Page: 1, Limit: 10, Filters: []model.Filter{
+				{Column: "tag", Type: "eq", Value: "a"},
+				{Column: "tag", Type: "eq", Value: "b"},
+				{Column: "year", Type: "gte", Value: "1990"},
+				{Column: "year", Type: "lte", Value: "1999"},
+			}},
+		},
+		{
+			name:  "sort keys with - for descending",
+			query: "sort=-created_at, last_name",
+			want: model.GetListFilter{Page: 1, Limit: 10, OrderBy: []model.OrderBy{
+				{Column: "created_at", Order: "desc"},
+				{Column: "last_name", Order: "asc"},
+			}},
+		},
+		{
+			name:  "cursor and count",
+			query: "after=abc&count=false",
+			want:  model.GetListFilter{Page: 1, Limit: 10, After: "abc", SkipCount: true},
+		},
+		{
+			name:  "reserved parameters are not filters",
+			query: "q=heat&facets=genre&before=xyz",
+			want:  model.GetListFilter{Page: 1, Limit: 10, Before: "xyz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := ParseQuery(values, opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseQueryRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"page zero", "page=0"},
+		{"negative page", "page=-1"},
+		{"non-numeric page", "page=two"},
+		{"limit zero", "limit=0"},
+		{"negative limit", "limit=-5"},
+		{"non-numeric limit", "limit=all"},
+		{"after with before", "after=a&before=b"},
+		{"bad count", "count=maybe"},
+		{"empty sort key", "sort=year,,title"},
+		{"bare minus sort key", "sort=-"},
+		{"unclosed operator", "year[gte=1990"},
+		{"missing field", "[eq]=1"},
+		{"missing operator", "year[]=1990"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := ParseQuery(values, QueryOptions{}); !errors.Is(err, model.ErrInvalidFilter) {
+				t.Errorf("got error %v, want %v", err, model.ErrInvalidFilter)
+			}
+		})
+	}
+}
+
+// Unknown parameters parse as filters and are refused by the schema, which
+// handlers answer with 400.
+func TestParseQueryUnknownParameter(t *testing.T) {
+	for _, query := range []string{"utm_source=mail", "year[like]=199", "password[eq]=x"} {
+		values, _ := url.ParseQuery(query)
+		req, err := ParseQuery(values, QueryOptions{})
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error: %v", query, err)
+		}
+		if _, err := itemFields.Filters(dryRun(t), req.Filters); !errors.Is(err, model.ErrInvalidFilter) {
+			t.Errorf("%s: got error %v, want %v", query, err, model.ErrInvalidFilter)
+		}
+	}
+}
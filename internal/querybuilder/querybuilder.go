@@ -0,0 +1,344 @@
+// Package querybuilder turns client supplied filters and sort keys into SQL
+// without letting them name arbitrary columns. Each entity declares a Schema
+// mapping public field names to columns; anything outside it is rejected
+// with an error wrapping model.ErrInvalidFilter.
+package querybuilder
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/movie-app/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Operator string
+
+const (
+	Eq      Operator = "eq"
+	Ne      Operator = "ne"
+	Gt      Operator = "gt"
+	Gte     Operator = "gte"
+	Lt      Operator = "lt"
+	Lte     Operator = "lte"
+	Search  Operator = "search"
+	In      Operator = "in"
+	Between Operator = "between"
+	IsNull  Operator = "is_null"
+	// All takes a list like In but requires every value to match. Only
+	// virtual fields can support it.
+	All Operator = "all"
+)
+
+type Type int
+
+const (
+	String Type = iota
+	Int
+	Float
+	Bool
+	Time
+)
+
+// FilterFunc applies a virtual field, one that is not a plain column. The
+// values are already split for In and Between.
+type FilterFunc func(db *gorm.DB, op Operator, values []string) *gorm.DB
+
+// Field describes one public field of an entity.
+type Field struct {
+	// Column is the SQL expression the field maps to. It comes from code,
+	// never from the request.
+	Column string
+	Type   Type
+	// Ops restricts the operators allowed on the field. Empty means the
+	// defaults for Type.
+	Ops       []Operator
+	Sortable  bool
+	Updatable bool
+	// Nullable allows bulk updates to set the field to null.
+	Nullable bool
+	// Filter makes the field virtual. Virtual fields cannot be sorted or
+	// updated.
+	Filter FilterFunc
+}
+
+// Schema maps public field names to fields.
+type Schema map[string]Field
+
+var defaultOps = map[Type][]Operator{
+	String: {Eq, Ne, Search, In, IsNull},
+	Int:    {Eq, Ne, Gt, Gte, Lt, Lte, In, Between, IsNull},
+	Float:  {Eq, Ne, Gt, Gte, Lt, Lte, Between, IsNull},
+	Bool:   {Eq, Ne, IsNull},
+	Time:   {Eq, Ne, Gt, Gte, Lt, Lte, Between, IsNull},
+}
+
+// Filter validates f against the schema and adds it to db.
+func (s Schema) Filter(db *gorm.DB, f model.Filter) (*gorm.DB, error) {
+	field, err := s.field(f.Column)
+	if err != nil {
+		return nil, err
+	}
+
+	op := Operator(strings.ToLower(f.Type))
+	if op == "" {
+		op = Eq
+	}
+	if !slices.Contains(field.ops(), op) || (op == All && field.Filter == nil) {
+		return nil, invalid("operator %q is not supported on %q (allowed: %s)", f.Type, f.Column, joinOps(field.ops()))
+	}
+
+	values, err := split(op, f.Value)
+	if err != nil {
+		return nil, invalid("%s on %q: %v", op, f.Column, err)
+	}
+
+	if op == IsNull {
+		isNull, err := strconv.ParseBool(values[0])
+		if err != nil {
+			return nil, invalid("is_null on %q expects true or false", f.Column)
+		}
+		if field.Filter != nil {
+			return field.Filter(db, op, values), nil
+		}
+		if isNull {
+			return db.Where(field.Column + " IS NULL"), nil
+		}
+		return db.Where(field.Column + " IS NOT NULL"), nil
+	}
+
+	args := make([]any, len(values))
+	for i, v := range values {
+		if op == Search {
+			args[i] = v
+			continue
+		}
+		if args[i], err = field.Type.parse(v); err != nil {
+			return nil, invalid("invalid value %q for %q: %v", v, f.Column, err)
+		}
+	}
+
+	if field.Filter != nil {
+		return field.Filter(db, op, values), nil
+	}
+
+	col := field.Column
+	switch op {
+	case Eq:
+		return db.Where(col+" = ?", args[0]), nil
+	case Ne:
+		return db.Where(col+" <> ?", args[0]), nil
+	case Gt:
+		return db.Where(col+" > ?", args[0]), nil
+	case Gte:
+		return db.Where(col+" >= ?", args[0]), nil
+	case Lt:
+		return db.Where(col+" < ?", args[0]), nil
+	case Lte:
+		return db.Where(col+" <= ?", args[0]), nil
+	case Search:
+		return db.Where(col+" ILIKE ?", "%"+EscapeLike(values[0])+"%"), nil
+	case In:
+		return db.Where(col+" IN ?", args), nil
+	case Between:
+		return db.Where(col+" BETWEEN ? AND ?", args[0], args[1]), nil
+	}
+	return nil, invalid("operator %q is not supported", f.Type)
+}
+
+// Filters applies every filter in order.
+func (s Schema) Filters(db *gorm.DB, filters []model.Filter) (*gorm.DB, error) {
+	var err error
+	for _, f := range filters {
+		if db, err = s.Filter(db, f); err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// Order validates the sort keys and adds them to db. Directions are "asc"
+// (the default) or "desc".
+func (s Schema) Order(db *gorm.DB, orders []model.OrderBy) (*gorm.DB, error) {
+	for _, o := range orders {
+		field, err := s.field(o.Column)
+		if err != nil {
+			return nil, err
+		}
+		if !field.Sortable || field.Filter != nil {
+			return nil, invalid("cannot sort by %q (sortable: %s)", o.Column, strings.Join(s.names(func(f Field) bool { return f.Sortable }), ", "))
+		}
+
+		var desc bool
+		switch strings.ToLower(o.Order) {
+		case "", "asc":
+		case "desc":
+			desc = true
+		default:
+			return nil, invalid("invalid sort direction %q for %q, expected asc or desc", o.Order, o.Column)
+		}
+
+		db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Column, Raw: true}, Desc: desc})
+	}
+	return db, nil
+}
+
+// Updatable resolves a field that may be written by bulk updates to its
+// bare column name, as SET requires, and converts value to the field's type.
+func (s Schema) Updatable(name string, value any) (string, any, error) {
+	field, err := s.field(name)
+	if err != nil {
+		return "", nil, err
+	}
+	if !field.Updatable || field.Filter != nil {
+		return "", nil, invalid("field %q cannot be updated (updatable: %s)", name, strings.Join(s.names(func(f Field) bool { return f.Updatable }), ", "))
+	}
+
+	column := bareColumn(field.Column)
+
+	if value == nil {
+		if !field.Nullable {
+			return "", nil, invalid("field %q cannot be null", name)
+		}
+		return column, nil, nil
+	}
+	v, err := field.Type.coerce(value)
+	if err != nil {
+		return "", nil, invalid("invalid value %v for %q: %v", value, name, err)
+	}
+	return column, v, nil
+}
+
+func (s Schema) field(name string) (Field, error) {
+	field, ok := s[name]
+	if !ok {
+		return Field{}, invalid("unknown field %q (allowed: %s)", name, strings.Join(s.names(nil), ", "))
+	}
+	return field, nil
+}
+
+func (s Schema) names(keep func(Field) bool) []string {
+	names := make([]string, 0, len(s))
+	for name, f := range s {
+		if keep == nil || keep(f) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f Field) ops() []Operator {
+	if len(f.Ops) > 0 {
+		return f.Ops
+	}
+	return defaultOps[f.Type]
+}
+
+// coerce converts a JSON decoded value to the type. Numbers and booleans
+// may also be given as strings; anything else must match the type.
+func (t Type) coerce(value any) (any, error) {
+	switch v := value.(type) {
+	case string:
+		return t.parse(v)
+	case float64:
+		switch t {
+		case Int:
+			if v != math.Trunc(v) || v > math.MaxInt32 || v < math.MinInt32 {
+				return nil, fmt.Errorf("expected an integer")
+			}
+			return int(v), nil
+		case Float:
+			return v, nil
+		}
+	case int:
+		switch t {
+		case Int:
+			return v, nil
+		case Float:
+			return float64(v), nil
+		}
+	case bool:
+		if t == Bool {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("expected %s", t.describe())
+}
+
+func (t Type) describe() string {
+	switch t {
+	case Int:
+		return "an integer"
+	case Float:
+		return "a number"
+	case Bool:
+		return "a boolean"
+	case Time:
+		return "a date or RFC 3339 time"
+	}
+	return "a string"
+}
+
+func (t Type) parse(v string) (any, error) {
+	switch t {
+	case Int:
+		return strconv.Atoi(v)
+	case Float:
+		return strconv.ParseFloat(v, 64)
+	case Bool:
+		return strconv.ParseBool(v)
+	case Time:
+		if d, err := time.Parse(time.DateOnly, v); err == nil {
+			return d, nil
+		}
+		return time.Parse(time.RFC3339, v)
+	}
+	return v, nil
+}
+
+// split breaks a raw value into the operands op expects: a comma separated
+// list for In and All, exactly two for Between and one otherwise.
+func split(op Operator, raw string) ([]string, error) {
+	switch op {
+	case In, All:
+		parts := strings.Split(raw, ",")
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+			if parts[i] == "" {
+				return nil, fmt.Errorf("empty value in list")
+			}
+		}
+		return parts, nil
+	case Between:
+		parts := strings.Split(raw, ",")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("expected two comma separated values")
+		}
+		return []string{strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])}, nil
+	}
+	return []string{raw}, nil
+}
+
+// EscapeLike escapes the LIKE wildcards in s so it matches literally.
+func EscapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+func joinOps(ops []Operator) string {
+	s := make([]string, len(ops))
+	for i, op := range ops {
+		s[i] = string(op)
+	}
+	return strings.Join(s, ", ")
+}
+
+func invalid(format string, args ...any) error {
+	return fmt.Errorf("%w: "+format, append([]any{model.ErrInvalidFilter}, args...)...)
+}
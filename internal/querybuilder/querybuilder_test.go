@@ -0,0 +1,259 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/movie-app/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type item struct {
+	ID        int
+	Title     string
+	Year      int
+	Rating    float64
+	Plot      *string
+	Active    bool
+	CreatedAt time.Time
+}
+
+var itemFields = Schema{
+	"id":         {Column: "items.id", Type: Int, Sortable: true},
+	"title":      {Column: "items.title", Type: String, Sortable: true, Updatable: true},
+	"year":       {Column: "items.year", Type: Int, Sortable: true, Updatable: true},
+	"rating":     {Column: "items.rating", Type: Float, Sortable: true, Updatable: true},
+	"plot":       {Column: "items.plot", Type: String, Updatable: true, Nullable: true},
+	"active":     {Column: "items.active", Type: Bool, Updatable: true},
+	"created_at": {Column: "items.created_at", Type: Time, Sortable: true},
+	"tag": {Type: String, Ops: []Operator{Eq, In, All}, Filter: func(db *gorm.DB, op Operator, values []string) *gorm.DB {
+		return db.Where("tag_filter(?, ?)", string(op), strings.Join(values, "|"))
+	}},
+}
+
+// dryRun returns a DB that builds statements without a database.
+func dryRun(t testing.TB) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db.Model(&item{})
+}
+
+// build runs db as a SELECT and returns the SQL after FROM with its
+// arguments.
+func build(t testing.TB, db *gorm.DB) (string, []any) {
+	t.Helper()
+	stmt := db.Find(&[]item{}).Statement
+	sql := stmt.SQL.String()
+	_, rest, _ := strings.Cut(sql, `FROM "items" `)
+	return rest, stmt.Vars
+}
+
+func TestSchemaFilter(t *testing.T) {
+	day := time.Date(1999, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		filter   model.Filter
+		wantSQL  string
+		wantArgs []any
+		wantErr  bool
+	}{
+		{"eq is the default", model.Filter{Column: "year", Value: "1999"}, "WHERE items.year = $1", []any{1999}, false},
+		{"operator is case insensitive", model.Filter{Column: "year", Type: "GTE", Value: "1999"}, "WHERE items.year >= $1", []any{1999}, false},
+		{"ne", model.Filter{Column: "title", Type: "ne", Value: "Heat"}, "WHERE items.title <> $1", []any{"Heat"}, false},
+		{"gt", model.Filter{Column: "rating", Type: "gt", Value: "7.5"}, "WHERE items.rating > $1", []any{7.5}, false},
+		{"lt", model.Filter{Column: "year", Type: "lt", Value: "2000"}, "WHERE items.year < $1", []any{2000}, false},
+		{"lte", model.Filter{Column: "created_at", Type: "lte", Value: "1999-03-31"}, "WHERE items.created_at <= $1", []any{day}, false},
+		{"time accepts RFC 3339", model.Filter{Column: "created_at", Type: "gt", Value: "1999-03-31T00:00:00Z"}, "WHERE items.created_at > $1", []any{day}, false},
+		{"bool", model.Filter{Column: "active", Value: "true"}, "WHERE items.active = $1", []any{true}, false},
+		{"search escapes wildcards", model.Filter{Column: "title", Type: "search", Value: `50%_off\`}, "WHERE items.title ILIKE $1", []any{`%50\%\_off\\%`}, false},
+		{"in", model.Filter{Column: "year", Type: "in", Value: "1999, 2000,2001"}, "WHERE items.year IN ($1,$2,$3)", []any{1999, 2000, 2001}, false},
+		{"between", model.Filter{Column: "year", Type: "between", Value: "1990,1999"}, "WHERE items.year BETWEEN $1 AND $2", []any{1990, 1999}, false},
+		{"is_null true", model.Filter{Column: "plot", Type: "is_null", Value: "true"}, "WHERE items.plot IS NULL", nil, false},
+		{"is_null false", model.Filter{Column: "plot", Type: "is_null", Value: "false"}, "WHERE items.plot IS NOT NULL", nil, false},
+		{"virtual field", model.Filter{Column: "tag", Type: "all", Value: "a,b"}, "WHERE tag_filter($1, $2)", []any{"all", "a|b"}, false},
+		{"values are bound, not spliced", model.Filter{Column: "title", Value: "x' OR '1'='1"}, "WHERE items.title = $1", []any{"x' OR '1'='1"}, false},
+
+		{"unknown field", model.Filter{Column: "password", Value: "x"}, "", nil, true},
+		{"column expression as field", model.Filter{Column: "items.title", Value: "x"}, "", nil, true},
+		{"injection in field", model.Filter{Column: "title = title OR 1=1 --", Value: "x"}, "", nil, true},
+		{"unknown operator", model.Filter{Column: "year", Type: "like", Value: "1"}, "", nil, true},
+		{"injection in operator", model.Filter{Column: "year", Type: "eq OR 1=1", Value: "1"}, "", nil, true},
+		{"operator not allowed for type", model.Filter{Column: "title", Type: "gt", Value: "a"}, "", nil, true},
+		{"operator not allowed for field", model.Filter{Column: "tag", Type: "search", Value: "a"}, "", nil, true},
+		{"all needs a virtual field", model.Filter{Column: "year", Type: "all", Value: "1,2"}, "", nil, true},
+		{"bad int", model.Filter{Column: "year", Value: "1999 OR 1=1"}, "", nil, true},
+		{"bad float", model.Filter{Column: "rating", Type: "gt", Value: "high"}, "", nil, true},
+		{"bad time", model.Filter{Column: "created_at", Type: "gt", Value: "yesterday"}, "", nil, true},
+		{"bad bool", model.Filter{Column: "active", Value: "yes please"}, "", nil, true},
+		{"bad is_null", model.Filter{Column: "plot", Type: "is_null", Value: "maybe"}, "", nil, true},
+		{"empty value in list", model.Filter{Column: "year", Type: "in", Value: "1999,,2000"}, "", nil, true},
+		{"between needs two values", model.Filter{Column: "year", Type: "between", Value: "1990"}, "", nil, true},
+		{"between takes only two values", model.Filter{Column: "year", Type: "between", Value: "1990,1995,1999"}, "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := itemFields.Filter(dryRun(t), tt.filter)
+			if tt.wantErr {
+				if !errors.Is(err, model.ErrInvalidFilter) {
+					t.Fatalf("got error %v, want %v", err, model.ErrInvalidFilter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			sql, args := build(t, db)
+			if sql != tt.wantSQL {
+				t.Errorf("got SQL %q, want %q", sql, tt.wantSQL)
+			}
+			if len(args) != 0 || len(tt.wantArgs) != 0 {
+				if !reflect.DeepEqual(args, tt.wantArgs) {
+					t.Errorf("got args %#v, want %#v", args, tt.wantArgs)
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaFilters(t *testing.T) {
+	db, err := itemFields.Filters(dryRun(t), []model.Filter{
+		{Column: "year", Type: "gte", Value: "1990"},
+		{Column: "title", Type: "search", Value: "heat"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql, args := build(t, db)
+	if want := "WHERE items.year >= $1 AND items.title ILIKE $2"; sql != want {
+		t.Errorf("got SQL %q, want %q", sql, want)
+	}
+	if want := []any{1990, "%heat%"}; !reflect.DeepEqual(args, want) {
+		t.Errorf("got args %#v, want %#v", args, want)
+	}
+
+	if _, err := itemFields.Filters(dryRun(t), []model.Filter{
+		{Column: "year", Value: "1990"},
+		{Column: "nope", Value: "1"},
+	}); !errors.Is(err, model.ErrInvalidFilter) {
+		t.Errorf("got error %v, want %v", err, model.ErrInvalidFilter)
+	}
+}
+
+func TestSchemaOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		orders  []model.OrderBy
+		wantSQL string
+		wantErr bool
+	}{
+		{"asc is the default", []model.OrderBy{{Column: "year"}}, "ORDER BY items.year", false},
+		{"explicit directions", []model.OrderBy{{Column: "year", Order: "DESC"}, {Column: "title", Order: "asc"}}, "ORDER BY items.year DESC,items.title", false},
+
+		{"unknown field", []model.OrderBy{{Column: "password"}}, "", true},
+		{"injection in field", []model.OrderBy{{Column: "year; DROP TABLE items"}}, "", true},
+		{"field not sortable", []model.OrderBy{{Column: "plot"}}, "", true},
+		{"virtual field", []model.OrderBy{{Column: "tag"}}, "", true},
+		{"bad direction", []model.OrderBy{{Column: "year", Order: "desc, (SELECT 1)"}}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, err := itemFields.Order(dryRun(t), tt.orders)
+			if tt.wantErr {
+				if !errors.Is(err, model.ErrInvalidFilter) {
+					t.Fatalf("got error %v, want %v", err, model.ErrInvalidFilter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql, _ := build(t, db); sql != tt.wantSQL {
+				t.Errorf("got SQL %q, want %q", sql, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestSchemaUpdatable(t *testing.T) {
+	tests := []struct {
+		name       string
+		field      string
+		value      any
+		wantColumn string
+		wantValue  any
+		wantErr    bool
+	}{
+		{"string", "title", "Heat", "title", "Heat", false},
+		{"JSON number to int", "year", float64(1999), "year", 1999, false},
+		{"int", "year", 1999, "year", 1999, false},
+		{"numeric string to int", "year", "1999", "year", 1999, false},
+		{"JSON number to float", "rating", float64(7.5), "rating", 7.5, false},
+		{"int to float", "rating", 7, "rating", float64(7), false},
+		{"bool", "active", true, "active", true, false},
+		{"null on a nullable field", "plot", nil, "plot", nil, false},
+
+		{"unknown field", "password", "x", "", nil, true},
+		{"field not updatable", "id", float64(1), "", nil, true},
+		{"virtual field", "tag", "a", "", nil, true},
+		{"null on a required field", "title", nil, "", nil, true},
+		{"fraction to int", "year", 1999.5, "", nil, true},
+		{"int out of range", "year", float64(1 << 40), "", nil, true},
+		{"bool to int", "year", true, "", nil, true},
+		{"number to bool", "active", float64(1), "", nil, true},
+		{"object", "title", map[string]any{"a": 1}, "", nil, true},
+		{"bad numeric string", "year", "1999; DROP TABLE items", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			column, value, err := itemFields.Updatable(tt.field, tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, model.ErrInvalidFilter) {
+					t.Fatalf("got error %v, want %v", err, model.ErrInvalidFilter)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if column != tt.wantColumn || !reflect.DeepEqual(value, tt.wantValue) {
+				t.Errorf("got %q = %#v, want %q = %#v", column, value, tt.wantColumn, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestEscapeLike(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"100%", `100\%`},
+		{"snake_case", `snake\_case`},
+		{`back\slash`, `back\\slash`},
+		{`\%_`, `\\\%\_`},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := EscapeLike(tt.in); got != tt.want {
+			t.Errorf("EscapeLike(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Package rbac defines user roles and the permissions each role is granted.
+// The policy is a plain lookup table so it can be checked without a database.
+package rbac
+
+type Role string
+
+const (
+	RoleViewer Role = "viewer"
+	RoleEditor Role = "editor"
+	RoleAdmin  Role = "admin"
+)
+
+// Permission doubles as an API key scope.
+type Permission string
+
+const (
+	MoviesRead    Permission = "movies:read"
+	MoviesWrite   Permission = "movies:write"
+	MoviesDelete  Permission = "movies:delete"
+	ActorsRead    Permission = "actors:read"
+	ActorsWrite   Permission = "actors:write"
+	ActorsDelete  Permission = "actors:delete"
+	GenresRead    Permission = "genres:read"
+	GenresWrite   Permission = "genres:write"
+	GenresDelete  Permission = "genres:delete"
+	ReviewsWrite  Permission = "reviews:write"
+	ListsWrite    Permission = "lists:write"
+	HistoryWrite  Permission = "history:write"
+	UsersManage   Permission = "users:manage"
+	APIKeysManage Permission = "api_keys:manage"
+)
+
+var permissions = map[Permission]struct{}{
+	MoviesRead:    {},
+	MoviesWrite:   {},
+	MoviesDelete:  {},
+	ActorsRead:    {},
+	ActorsWrite:   {},
+	ActorsDelete:  {},
+	GenresRead:    {},
+	GenresWrite:   {},
+	GenresDelete:  {},
+	ReviewsWrite:  {},
+	ListsWrite:    {},
+	HistoryWrite:  {},
+	UsersManage:   {},
+	APIKeysManage: {},
+}
+
+var policy = map[Role]map[Permission]struct{}{
+	RoleViewer: {
+		MoviesRead:   {},
+		ActorsRead:   {},
+		GenresRead:   {},
+		ReviewsWrite: {},
+		ListsWrite:   {},
+		HistoryWrite: {},
+	},
+	RoleEditor: {
+		MoviesRead:   {},
+		MoviesWrite:  {},
+		ActorsRead:   {},
+		ActorsWrite:  {},
+		GenresRead:   {},
+		GenresWrite:  {},
+		ReviewsWrite: {},
+		ListsWrite:   {},
+		HistoryWrite: {},
+	},
+	RoleAdmin: permissions,
+}
+
+// Valid reports whether r is one of the known roles.
+func (r Role) Valid() bool {
+	_, ok := policy[r]
+	return ok
+}
+
+// Valid reports whether p is one of the known permissions.
+func (p Permission) Valid() bool {
+	_, ok := permissions[p]
+	return ok
+}
+
+// Can reports whether role is granted perm. Unknown roles, including the
+// empty role of anonymous callers, are granted nothing.
+func Can(role Role, perm Permission) bool {
+	_, ok := policy[role][perm]
+	return ok
+}
+
+// HasScope reports whether perm is among scopes.
+func HasScope(scopes []string, perm Permission) bool {
+	for _, scope := range scopes {
+		if Permission(scope) == perm {
+			return true
+		}
+	}
+	return false
+}
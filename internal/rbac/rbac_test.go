@@ -0,0 +1,99 @@
+package rbac
+
+import "testing"
+
+func TestCan(t *testing.T) {
+	tests := []struct {
+		perm                  Permission
+		viewer, editor, admin bool
+	}{
+		{MoviesRead, true, true, true},
+		{MoviesWrite, false, true, true},
+		{MoviesDelete, false, false, true},
+		{ActorsRead, true, true, true},
+		{ActorsWrite, false, true, true},
+		{ActorsDelete, false, false, true},
+		{GenresRead, true, true, true},
+		{GenresWrite, false, true, true},
+		{GenresDelete, false, false, true},
+		{ReviewsWrite, true, true, true},
+		{ListsWrite, true, true, true},
+		{HistoryWrite, true, true, true},
+		{UsersManage, false, false, true},
+		{APIKeysManage, false, false, true},
+	}
+
+	covered := make(map[Permission]bool, len(tests))
+	for _, tt := range tests {
+		covered[tt.perm] = true
+		for _, c := range []struct {
+			role Role
+			want bool
+		}{
+			{RoleViewer, tt.viewer},
+			{RoleEditor, tt.editor},
+			{RoleAdmin, tt.admin},
+			{"", false},
+			{"superuser", false},
+		} {
+			if got := Can(c.role, tt.perm); got != c.want {
+				t.Errorf("Can(%q, %q) = %v, want %v", c.role, tt.perm, got, c.want)
+			}
+		}
+	}
+
+	for perm := range permissions {
+		if !covered[perm] {
+			t.Errorf("permission %q is missing from the table", perm)
+		}
+	}
+}
+
+func TestCanUnknownPermission(t *testing.T) {
+	for _, role := range []Role{RoleViewer, RoleEditor, RoleAdmin} {
+		if Can(role, "movies:everything") {
+			t.Errorf("Can(%q, unknown permission) = true", role)
+		}
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+		perm   Permission
+		want   bool
+	}{
+		{"granted", []string{"movies:read", "actors:read"}, ActorsRead, true},
+		{"not granted", []string{"movies:read"}, MoviesWrite, false},
+		{"no scopes", nil, MoviesRead, false},
+		{"write does not imply read", []string{"movies:write"}, MoviesRead, false},
+		{"prefix is not a match", []string{"movies"}, MoviesRead, false},
+		{"case sensitive", []string{"Movies:Read"}, MoviesRead, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasScope(tt.scopes, tt.perm); got != tt.want {
+				t.Errorf("HasScope(%v, %q) = %v, want %v", tt.scopes, tt.perm, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, role := range []Role{RoleViewer, RoleEditor, RoleAdmin} {
+		if !role.Valid() {
+			t.Errorf("%q.Valid() = false", role)
+		}
+	}
+	if Role("owner").Valid() {
+		t.Error(`"owner".Valid() = true`)
+	}
+	if !MoviesRead.Valid() {
+		t.Errorf("%q.Valid() = false", MoviesRead)
+	}
+	if Permission("movies:*").Valid() {
+		t.Error(`"movies:*".Valid() = true`)
+	}
+}
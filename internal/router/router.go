@@ -43,10 +43,23 @@ func RegisterHooks(lc fx.Lifecycle, router *gin.Engine, cfg *config.Config) {
 // @securityDefinitions.apikey BearerAuth
 // @in header
 // @name Authorization
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
 func SetupRoutes(
 	router *gin.Engine,
 	movieHandler *handler.MovieHandler,
 	actorHandler *handler.ActorHandler,
+	genreHandler *handler.GenreHandler,
+	creditHandler *handler.CreditHandler,
+	reviewHandler *handler.ReviewHandler,
+	rankingHandler *handler.RankingHandler,
+	searchHandler *handler.SearchHandler,
+	listHandler *handler.ListHandler,
+	watchHandler *handler.WatchHandler,
+	authHandler *handler.AuthHandler,
+	userHandler *handler.UserHandler,
+	apiKeyHandler *handler.APIKeyHandler,
 ) {
 
 	// Swagger
@@ -58,8 +71,18 @@ func SetupRoutes(
 	// Prometheus metrics
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	authHandler.RegisterRoutes(router)
+	userHandler.RegisterRoutes(router)
+	apiKeyHandler.RegisterRoutes(router)
 	movieHandler.RegisterRoutes(router)
 	actorHandler.RegisterRoutes(router)
+	genreHandler.RegisterRoutes(router)
+	creditHandler.RegisterRoutes(router)
+	reviewHandler.RegisterRoutes(router)
+	rankingHandler.RegisterRoutes(router)
+	searchHandler.RegisterRoutes(router)
+	listHandler.RegisterRoutes(router)
+	watchHandler.RegisterRoutes(router)
 }
 
 var Module = fx.Options(
@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/usecase"
+)
+
+// RegisterJobs wires the periodic maintenance tasks of the app.
+func RegisterJobs(s *Scheduler, uc *usecase.UseCase, cfg *config.Config) {
+	s.Add(Job{
+		Name:     "refresh_movie_rankings",
+		Interval: cfg.RankingRefreshInterval,
+		Run:      uc.RankingRepo.Refresh,
+	})
+	s.Add(Job{
+		Name:      "flush_watch_progress",
+		Interval:  cfg.ProgressFlushInterval,
+		Run:       uc.WatchProgressRepo.Flush,
+		RunOnStop: true,
+	})
+	s.Add(Job{
+		Name:     "delete_expired_idempotency_keys",
+		Interval: cfg.IdempotencyCleanupInterval,
+		Run:      uc.IdempotencyRepo.DeleteExpired,
+	})
+}
@@ -0,0 +1,9 @@
+package scheduler
+
+import "go.uber.org/fx"
+
+var Module = fx.Options(
+	fx.Provide(New),
+	fx.Invoke(RegisterJobs),
+	fx.Invoke(RegisterHooks),
+)
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/movie-app/pkg/logger"
+	"go.uber.org/fx"
+)
+
+// Job is a background task run every Interval while the app is up. Jobs
+// with RunOnStop run once more during shutdown, after the loops have ended.
+type Job struct {
+	Name      string
+	Interval  time.Duration
+	Run       func(ctx context.Context) error
+	RunOnStop bool
+}
+
+type Scheduler struct {
+	logger *logger.Logger
+	jobs   []Job
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func New(logger *logger.Logger) *Scheduler {
+	return &Scheduler{logger: logger}
+}
+
+// Add registers a job. Jobs must be added before the app starts.
+func (s *Scheduler) Add(job Job) {
+	s.jobs = append(s.jobs, job)
+}
+
+func (s *Scheduler) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			s.logger.Warn("scheduler: job %s disabled, interval is %s", job.Name, job.Interval)
+			continue
+		}
+		s.wg.Add(1)
+		go s.loop(ctx, job)
+	}
+}
+
+func (s *Scheduler) stop(ctx context.Context) error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	for _, job := range s.jobs {
+		if !job.RunOnStop {
+			continue
+		}
+		if err := job.Run(ctx); err != nil {
+			s.logger.Error("scheduler: final run of %s failed: %v", job.Name, err)
+		}
+	}
+	return nil
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+				s.logger.Error("scheduler: job %s failed: %v", job.Name, err)
+			}
+		}
+	}
+}
+
+func RegisterHooks(lc fx.Lifecycle, s *Scheduler) {
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			s.start()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return s.stop(ctx)
+		},
+	})
+}
@@ -12,7 +12,8 @@ type (
 		GetSingle(ctx context.Context, req model.Id) (model.Movie, error)
 		UpdateField(ctx context.Context, req model.UpdateFieldRequest) (model.RowsEffected, error)
 		Update(ctx context.Context, req model.Movie) (model.Movie, error)
-		Delete(ctx context.Context, req model.Id) error
+		Patch(ctx context.Context, id, version int, patch func(model.MovieDocument) (model.MovieDocument, error)) (model.Movie, error)
+		Delete(ctx context.Context, req model.Id, version int) error
 		GetList(ctx context.Context, req model.GetListFilter) (model.MovieList, error)
 	}
 
@@ -20,7 +21,86 @@ type (
 		Create(ctx context.Context, actor model.Actor) (model.Actor, error)
 		GetByID(ctx context.Context, id uint) (model.Actor, error)
 		Update(ctx context.Context, actor model.Actor) (model.Actor, error)
-		Delete(ctx context.Context, id uint) error
+		Patch(ctx context.Context, id, version int, patch func(model.ActorDocument) (model.ActorDocument, error)) (model.Actor, error)
+		Delete(ctx context.Context, id uint, version int) error
 		GetList(ctx context.Context, req model.GetListFilter) (model.ActorList, error)
 	}
+
+	GenreRepoI interface {
+		Create(ctx context.Context, genre model.Genre) (model.Genre, error)
+		GetByID(ctx context.Context, id int) (model.Genre, error)
+		Update(ctx context.Context, genre model.Genre) (model.Genre, error)
+		Delete(ctx context.Context, id int) error
+		GetList(ctx context.Context, req model.GetListFilter) (model.GenreList, error)
+	}
+
+	CreditRepoI interface {
+		GetMovieCredits(ctx context.Context, movieID int) (model.MovieCredits, error)
+		GetFilmography(ctx context.Context, personID int) (model.Filmography, error)
+	}
+
+	ReviewRepoI interface {
+		Create(ctx context.Context, review model.Review) (model.Review, error)
+		Update(ctx context.Context, review model.Review) (model.Review, error)
+		Delete(ctx context.Context, movieID, userID int) error
+		GetList(ctx context.Context, movieID int, req model.GetListFilter) (model.ReviewList, error)
+	}
+
+	RankingRepoI interface {
+		Refresh(ctx context.Context) error
+		GetTop(ctx context.Context, req model.TopRatedFilter) (model.TopRatedList, error)
+	}
+
+	SearchRepoI interface {
+		Search(ctx context.Context, req model.SearchRequest) (model.SearchResults, error)
+		Autocomplete(ctx context.Context, req model.AutocompleteRequest) (model.Suggestions, error)
+	}
+
+	ListRepoI interface {
+		Create(ctx context.Context, list model.List) (model.List, error)
+		GetDefault(ctx context.Context, userID int) (model.List, error)
+		GetByUser(ctx context.Context, userID int) ([]model.List, error)
+		Get(ctx context.Context, req model.Id) (model.List, error)
+		GetItems(ctx context.Context, listID int) ([]model.ListEntry, error)
+		Update(ctx context.Context, list model.List) (model.List, error)
+		Delete(ctx context.Context, id int) error
+		AddItem(ctx context.Context, listID, movieID, position int) error
+		RemoveItem(ctx context.Context, listID, movieID int) error
+		Reorder(ctx context.Context, listID int, movieIDs []int) error
+	}
+
+	WatchProgressRepoI interface {
+		Record(ctx context.Context, p model.WatchProgress) error
+		Flush(ctx context.Context) error
+		GetInProgress(ctx context.Context, userID, limit int) ([]model.WatchEntry, error)
+		GetHistory(ctx context.Context, userID int, req model.GetListFilter) (model.WatchHistory, error)
+	}
+
+	UserRepoI interface {
+		Create(ctx context.Context, user model.User) (model.User, error)
+		GetByEmail(ctx context.Context, email string) (model.User, error)
+		GetByID(ctx context.Context, id int) (model.User, error)
+		UpdateRole(ctx context.Context, id int, role string) (model.User, error)
+	}
+
+	RefreshTokenRepoI interface {
+		Create(ctx context.Context, token model.RefreshToken) (model.RefreshToken, error)
+		Rotate(ctx context.Context, tokenHash string, next model.RefreshToken) (model.RefreshToken, error)
+		RevokeByHash(ctx context.Context, tokenHash string) error
+		IsFamilyActive(ctx context.Context, familyID string) (bool, error)
+	}
+
+	APIKeyRepoI interface {
+		Create(ctx context.Context, key model.APIKey) (model.APIKey, error)
+		List(ctx context.Context) ([]model.APIKey, error)
+		Revoke(ctx context.Context, id int) error
+		Authenticate(ctx context.Context, keyHash string) (model.APIKey, error)
+	}
+
+	IdempotencyRepoI interface {
+		Reserve(ctx context.Context, rec model.IdempotencyKey) (model.IdempotencyKey, bool, error)
+		Complete(ctx context.Context, owner, key string, status int, contentType string, body []byte) error
+		Release(ctx context.Context, owner, key string) error
+		DeleteExpired(ctx context.Context) error
+	}
 )
@@ -1,17 +1,50 @@
 package usecase
 
 type UseCase struct {
-	MovieRepo MovieRepoI
-	ActorRepo ActorRepoI
+	MovieRepo         MovieRepoI
+	ActorRepo         ActorRepoI
+	GenreRepo         GenreRepoI
+	CreditRepo        CreditRepoI
+	ReviewRepo        ReviewRepoI
+	RankingRepo       RankingRepoI
+	SearchRepo        SearchRepoI
+	ListRepo          ListRepoI
+	WatchProgressRepo WatchProgressRepoI
+	UserRepo          UserRepoI
+	RefreshTokenRepo  RefreshTokenRepoI
+	APIKeyRepo        APIKeyRepoI
+	IdempotencyRepo   IdempotencyRepoI
 }
 
 func NewUseCase(
 	movieRepo MovieRepoI,
 	actorRepo ActorRepoI,
+	genreRepo GenreRepoI,
+	creditRepo CreditRepoI,
+	reviewRepo ReviewRepoI,
+	rankingRepo RankingRepoI,
+	searchRepo SearchRepoI,
+	listRepo ListRepoI,
+	watchProgressRepo WatchProgressRepoI,
+	userRepo UserRepoI,
+	refreshTokenRepo RefreshTokenRepoI,
+	apiKeyRepo APIKeyRepoI,
+	idempotencyRepo IdempotencyRepoI,
 
 ) *UseCase {
 	return &UseCase{
-		MovieRepo: movieRepo,
-		ActorRepo: actorRepo,
+		MovieRepo:         movieRepo,
+		ActorRepo:         actorRepo,
+		GenreRepo:         genreRepo,
+		CreditRepo:        creditRepo,
+		ReviewRepo:        reviewRepo,
+		RankingRepo:       rankingRepo,
+		SearchRepo:        searchRepo,
+		ListRepo:          listRepo,
+		WatchProgressRepo: watchProgressRepo,
+		UserRepo:          userRepo,
+		RefreshTokenRepo:  refreshTokenRepo,
+		APIKeyRepo:        apiKeyRepo,
+		IdempotencyRepo:   idempotencyRepo,
 	}
 }
@@ -11,12 +11,56 @@ func provideMovieRepoInterface(r *repo.MovieRepo) MovieRepoI {
 func provideActorRepoInterface(r *repo.ActorRepo) ActorRepoI {
 	return r
 }
+func provideGenreRepoInterface(r *repo.GenreRepo) GenreRepoI {
+	return r
+}
+func provideCreditRepoInterface(r *repo.CreditRepo) CreditRepoI {
+	return r
+}
+func provideReviewRepoInterface(r *repo.ReviewRepo) ReviewRepoI {
+	return r
+}
+func provideRankingRepoInterface(r *repo.RankingRepo) RankingRepoI {
+	return r
+}
+func provideSearchRepoInterface(r *repo.SearchRepo) SearchRepoI {
+	return r
+}
+func provideListRepoInterface(r *repo.ListRepo) ListRepoI {
+	return r
+}
+func provideWatchProgressRepoInterface(r *repo.WatchProgressRepo) WatchProgressRepoI {
+	return r
+}
+func provideUserRepoInterface(r *repo.UserRepo) UserRepoI {
+	return r
+}
+func provideRefreshTokenRepoInterface(r *repo.RefreshTokenRepo) RefreshTokenRepoI {
+	return r
+}
+func provideAPIKeyRepoInterface(r *repo.APIKeyRepo) APIKeyRepoI {
+	return r
+}
+func provideIdempotencyRepoInterface(r *repo.IdempotencyRepo) IdempotencyRepoI {
+	return r
+}
 
 var Module = fx.Options(
 	repo.Module,
 	fx.Provide(
 		provideMovieRepoInterface,
 		provideActorRepoInterface,
+		provideGenreRepoInterface,
+		provideCreditRepoInterface,
+		provideReviewRepoInterface,
+		provideRankingRepoInterface,
+		provideSearchRepoInterface,
+		provideListRepoInterface,
+		provideWatchProgressRepoInterface,
+		provideUserRepoInterface,
+		provideRefreshTokenRepoInterface,
+		provideAPIKeyRepoInterface,
+		provideIdempotencyRepoInterface,
 		NewUseCase,
 	),
 )
@@ -2,13 +2,35 @@ package repo
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/movie-app/internal/config"
 	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
 	"github.com/movie-app/pkg/logger"
 	"gorm.io/gorm"
 )
 
+// actorFields lists what clients may filter and sort actors by.
+var actorFields = querybuilder.Schema{
+	"id":         {Column: "actors.id", Type: querybuilder.Int, Sortable: true},
+	"first_name": {Column: "actors.first_name", Type: querybuilder.String, Sortable: true, Updatable: true},
+	"last_name":  {Column: "actors.last_name", Type: querybuilder.String, Sortable: true, Updatable: true},
+	"role":       {Column: "actors.role", Type: querybuilder.String, Sortable: true, Updatable: true},
+	"created_at": {Column: "actors.created_at", Type: querybuilder.Time, Sortable: true},
+	"updated_at": {Column: "actors.updated_at", Type: querybuilder.Time, Sortable: true},
+	"movie_id": {
+		Type:   querybuilder.Int,
+		Ops:    []querybuilder.Operator{querybuilder.Eq, querybuilder.In},
+		Filter: filterByMovie,
+	},
+	"movie_year": {
+		Type:   querybuilder.Int,
+		Ops:    []querybuilder.Operator{querybuilder.Eq, querybuilder.Gte, querybuilder.Lte, querybuilder.Between},
+		Filter: filterByMovieYear,
+	},
+}
+
 type ActorRepo struct {
 	db     *gorm.DB
 	logger *logger.Logger
@@ -38,78 +60,156 @@ func (r *ActorRepo) GetByID(ctx context.Context, id uint) (model.Actor, error) {
 	return actor, nil
 }
 
+// Update replaces the editable fields of an actor. Timestamps are kept by
+// the database rather than taken from the request. A non-zero Version must
+// match the actor's.
 func (r *ActorRepo) Update(ctx context.Context, actor model.Actor) (model.Actor, error) {
-	if err := r.db.WithContext(ctx).Save(&actor).Error; err != nil {
+	var updated model.Actor
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockVersion(tx, &model.Actor{}, actor.ID, actor.Version); err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Actor{}).Where("id = ?", actor.ID).Updates(map[string]any{
+			"first_name": actor.FirstName,
+			"last_name":  actor.LastName,
+			"role":       actor.Role,
+			"version":    nextVersion,
+			"updated_at": gorm.Expr("NOW()"),
+		}).Error; err != nil {
+			return err
+		}
+		return tx.First(&updated, actor.ID).Error
+	})
+	if err != nil {
 		return model.Actor{}, err
 	}
-	return actor, nil
+	return updated, nil
 }
 
-func (r *ActorRepo) Delete(ctx context.Context, id uint) error {
-	if err := r.db.WithContext(ctx).Delete(&model.Actor{}, id).Error; err != nil {
-		return err
+// Patch applies patch to the current document of an actor and writes the
+// fields that changed, holding the row lock in between. A non-zero version
+// must match the actor's.
+func (r *ActorRepo) Patch(ctx context.Context, id, version int, patch func(model.ActorDocument) (model.ActorDocument, error)) (model.Actor, error) {
+	var actor model.Actor
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockVersion(tx, &model.Actor{}, id, version); err != nil {
+			return err
+		}
+		if err := tx.First(&actor, id).Error; err != nil {
+			return err
+		}
+
+		current := model.ActorDocument{FirstName: actor.FirstName, LastName: actor.LastName, Role: actor.Role}
+		next, err := patch(current)
+		if err != nil {
+			return err
+		}
+		if next == current {
+			return nil
+		}
+
+		fields := map[string]any{"version": nextVersion, "updated_at": gorm.Expr("NOW()")}
+		if next.FirstName != current.FirstName {
+			fields["first_name"] = next.FirstName
+		}
+		if next.LastName != current.LastName {
+			fields["last_name"] = next.LastName
+		}
+		if next.Role != current.Role {
+			fields["role"] = next.Role
+		}
+		if err := tx.Model(&model.Actor{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+			return err
+		}
+		return tx.First(&actor, id).Error
+	})
+	if err != nil {
+		return model.Actor{}, err
 	}
-	return nil
+
+	return actor, nil
+}
+
+// Delete removes an actor. A non-zero version must match the actor's.
+func (r *ActorRepo) Delete(ctx context.Context, id uint, version int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockVersion(tx, &model.Actor{}, int(id), version); err != nil {
+			return err
+		}
+		return tx.Delete(&model.Actor{}, id).Error
+	})
 }
 
 func (r *ActorRepo) GetList(ctx context.Context, req model.GetListFilter) (model.ActorList, error) {
 	var (
 		actors []model.Actor
-		total  int64
+		list   model.ActorList
 	)
 
 	tx := r.db.WithContext(ctx).Model(&model.Actor{})
 
-	for _, filter := range req.Filters {
-		query := filter.Column
-		switch filter.Type {
-		case "eq":
-			tx = tx.Where(query+" = ?", filter.Value)
-		case "ne":
-			tx = tx.Where(query+" <> ?", filter.Value)
-		case "gt":
-			tx = tx.Where(query+" > ?", filter.Value)
-		case "gte":
-			tx = tx.Where(query+" >= ?", filter.Value)
-		case "lt":
-			tx = tx.Where(query+" < ?", filter.Value)
-		case "lte":
-			tx = tx.Where(query+" <= ?", filter.Value)
-		case "search":
-			tx = tx.Where(query+" ILIKE ?", "%"+filter.Value+"%")
-		default:
-			continue
+	tx, err := actorFields.Filters(tx, req.Filters)
+	if err != nil {
+		return model.ActorList{}, err
+	}
+
+	if !req.SkipCount {
+		var total int64
+		if err := tx.Count(&total).Error; err != nil {
+			return model.ActorList{}, err
 		}
+		list.Total = &total
 	}
 
-	if err := tx.Count(&total).Error; err != nil {
+	tx, page, err := actorFields.Paginate(tx, req)
+	if err != nil {
 		return model.ActorList{}, err
 	}
 
-	for _, order := range req.OrderBy {
-		orderStr := order.Column
-		if order.Order == "desc" {
-			orderStr += " desc"
-		} else {
-			orderStr += " asc"
-		}
-		tx = tx.Order(orderStr)
+	if err := tx.Find(&actors).Error; err != nil {
+		return model.ActorList{}, err
 	}
 
-	offset := (req.Page - 1) * req.Limit
-	if offset < 0 {
-		offset = 0
+	list.Actors, list.NextCursor, list.PrevCursor, err = querybuilder.Cursors(actorFields, r.db.WithContext(ctx), actors, page)
+	if err != nil {
+		return model.ActorList{}, err
 	}
-	if req.Limit == 0 {
-		req.Limit = 10
+	return list, nil
+}
+
+// filterByMovie keeps actors cast in any of the movies.
+func filterByMovie(query *gorm.DB, _ querybuilder.Operator, values []string) *gorm.DB {
+	ids := make([]int, len(values))
+	for i, v := range values {
+		ids[i], _ = strconv.Atoi(v) // validated by the schema
 	}
+	return query.Where(`EXISTS (
+		SELECT 1 FROM movie_actors
+		WHERE movie_actors.actor_id = actors.id AND movie_actors.movie_id IN ?)`, ids)
+}
 
-	if err := tx.Offset(offset).Limit(req.Limit).Find(&actors).Error; err != nil {
-		return model.ActorList{}, err
+// filterByMovieYear keeps actors cast in at least one movie released in the
+// year range. Both bounds go into a single EXISTS so they apply to the same
+// movie.
+func filterByMovieYear(query *gorm.DB, op querybuilder.Operator, values []string) *gorm.DB {
+	var cond string
+	switch op {
+	case querybuilder.Gte:
+		cond = "movies.year >= ?"
+	case querybuilder.Lte:
+		cond = "movies.year <= ?"
+	case querybuilder.Between:
+		cond = "movies.year BETWEEN ? AND ?"
+	default:
+		cond = "movies.year = ?"
 	}
 
-	return model.ActorList{
-		Actors: actors,
-		Total:  total,
-	}, nil
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i], _ = strconv.Atoi(v) // validated by the schema
+	}
+	return query.Where(`EXISTS (
+		SELECT 1 FROM movie_actors
+		JOIN movies ON movies.id = movie_actors.movie_id
+		WHERE movie_actors.actor_id = actors.id AND `+cond+`)`, args...)
 }
@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type APIKeyRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewAPIKeyRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *APIKeyRepo {
+	return &APIKeyRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, key model.APIKey) (model.APIKey, error) {
+	if err := r.db.WithContext(ctx).Create(&key).Error; err != nil {
+		return model.APIKey{}, err
+	}
+	return key, nil
+}
+
+func (r *APIKeyRepo) List(ctx context.Context) ([]model.APIKey, error) {
+	keys := []model.APIKey{}
+	if err := r.db.WithContext(ctx).Order("id desc").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, id int) error {
+	res := r.db.WithContext(ctx).Model(&model.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", gorm.Expr("NOW()"))
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// Authenticate looks up an unrevoked key by its hash and records its use.
+func (r *APIKeyRepo) Authenticate(ctx context.Context, keyHash string) (model.APIKey, error) {
+	var key model.APIKey
+	res := r.db.WithContext(ctx).Model(&key).
+		Clauses(clause.Returning{}).
+		Where("key_hash = ? AND revoked_at IS NULL", keyHash).
+		Update("last_used_at", gorm.Expr("NOW()"))
+	if res.Error != nil {
+		return model.APIKey{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.APIKey{}, model.ErrNotFound
+	}
+	return key, nil
+}
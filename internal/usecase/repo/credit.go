@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type CreditRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewCreditRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *CreditRepo {
+	return &CreditRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (r *CreditRepo) GetMovieCredits(ctx context.Context, movieID int) (model.MovieCredits, error) {
+	db := r.db.WithContext(ctx)
+
+	var exists int64
+	if err := db.Model(&model.Movie{}).Where("id = ?", movieID).Count(&exists).Error; err != nil {
+		return model.MovieCredits{}, err
+	}
+	if exists == 0 {
+		return model.MovieCredits{}, model.ErrNotFound
+	}
+
+	movies := []model.Movie{{ID: movieID}}
+	if err := loadCast(db, movies); err != nil {
+		return model.MovieCredits{}, err
+	}
+	if err := loadCrew(db, movies); err != nil {
+		return model.MovieCredits{}, err
+	}
+
+	return model.MovieCredits{
+		MovieID: movieID,
+		Cast:    movies[0].Cast,
+		Crew:    movies[0].Crew,
+	}, nil
+}
+
+func (r *CreditRepo) GetFilmography(ctx context.Context, personID int) (model.Filmography, error) {
+	db := r.db.WithContext(ctx)
+
+	var person model.Actor
+	if err := db.First(&person, personID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Filmography{}, model.ErrNotFound
+		}
+		return model.Filmography{}, err
+	}
+
+	cast := []model.CastCredit{}
+	if err := db.Table("movie_actors").
+		Select("movies.id AS movie_id, movies.title AS movie_title, movies.year AS movie_year, "+
+			"COALESCE(movie_actors.character_name, '') AS character_name, movie_actors.billing_order").
+		Joins("JOIN movies ON movies.id = movie_actors.movie_id").
+		Where("movie_actors.actor_id = ?", personID).
+		Order("movies.year DESC, movies.id DESC").
+		Scan(&cast).Error; err != nil {
+		return model.Filmography{}, err
+	}
+
+	crew := []model.CrewCredit{}
+	if err := db.Table("credits").
+		Select("movies.id AS movie_id, movies.title AS movie_title, movies.year AS movie_year, "+
+			"credits.department, credits.job").
+		Joins("JOIN movies ON movies.id = credits.movie_id").
+		Where("credits.person_id = ?", personID).
+		Order("movies.year DESC, movies.id DESC, credits.department").
+		Scan(&crew).Error; err != nil {
+		return model.Filmography{}, err
+	}
+
+	return model.Filmography{
+		Person: person,
+		Cast:   cast,
+		Crew:   crew,
+	}, nil
+}
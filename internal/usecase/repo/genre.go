@@ -0,0 +1,113 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type GenreRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewGenreRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *GenreRepo {
+	return &GenreRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (r *GenreRepo) Create(ctx context.Context, genre model.Genre) (model.Genre, error) {
+	if err := r.db.WithContext(ctx).Create(&genre).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return model.Genre{}, model.ErrAlreadyExists
+		}
+		return model.Genre{}, err
+	}
+	return genre, nil
+}
+
+func (r *GenreRepo) GetByID(ctx context.Context, id int) (model.Genre, error) {
+	var genre model.Genre
+	if err := r.db.WithContext(ctx).First(&genre, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Genre{}, model.ErrNotFound
+		}
+		return model.Genre{}, err
+	}
+	return genre, nil
+}
+
+func (r *GenreRepo) Update(ctx context.Context, genre model.Genre) (model.Genre, error) {
+	res := r.db.WithContext(ctx).Model(&model.Genre{}).
+		Where("id = ?", genre.ID).
+		Updates(map[string]any{
+			"name":       genre.Name,
+			"updated_at": gorm.Expr("NOW()"),
+		})
+	if res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrDuplicatedKey) {
+			return model.Genre{}, model.ErrAlreadyExists
+		}
+		return model.Genre{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.Genre{}, model.ErrNotFound
+	}
+	return r.GetByID(ctx, genre.ID)
+}
+
+func (r *GenreRepo) Delete(ctx context.Context, id int) error {
+	res := r.db.WithContext(ctx).Delete(&model.Genre{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+func (r *GenreRepo) GetList(ctx context.Context, req model.GetListFilter) (model.GenreList, error) {
+	var (
+		genres []model.Genre
+		total  int64
+	)
+
+	tx := r.db.WithContext(ctx).Model(&model.Genre{})
+
+	for _, filter := range req.Filters {
+		if filter.Column == "name" && filter.Type == "search" {
+			tx = tx.Where(`name ILIKE ? ESCAPE '\'`, "%"+querybuilder.EscapeLike(filter.Value)+"%")
+		}
+	}
+
+	if err := tx.Count(&total).Error; err != nil {
+		return model.GenreList{}, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if offset < 0 {
+		offset = 0
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	if err := tx.Order("name asc").Offset(offset).Limit(req.Limit).Find(&genres).Error; err != nil {
+		return model.GenreList{}, err
+	}
+
+	return model.GenreList{
+		Genres: genres,
+		Total:  total,
+	}, nil
+}
@@ -0,0 +1,90 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type IdempotencyRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewIdempotencyRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *IdempotencyRepo {
+	return &IdempotencyRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Reserve claims rec.Key for rec.Owner. It reports true when the caller now
+// holds the key and should process the request, either because the key is
+// new or because the previous use has expired. Otherwise the stored record
+// is returned as it is.
+func (r *IdempotencyRepo) Reserve(ctx context.Context, rec model.IdempotencyKey) (model.IdempotencyKey, bool, error) {
+	db := r.db.WithContext(ctx)
+
+	// An expired row is taken over in place, so a key can be reused before
+	// the cleanup job has removed it.
+	res := db.Exec(`
+		INSERT INTO idempotency_keys (owner, key, fingerprint, expires_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (owner, key) DO UPDATE SET
+			fingerprint = EXCLUDED.fingerprint,
+			status_code = NULL,
+			content_type = NULL,
+			response = NULL,
+			created_at = NOW(),
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()`,
+		rec.Owner, rec.Key, rec.Fingerprint, rec.ExpiresAt)
+	if res.Error != nil {
+		return model.IdempotencyKey{}, false, res.Error
+	}
+	if res.RowsAffected == 1 {
+		return rec, true, nil
+	}
+
+	var stored model.IdempotencyKey
+	if err := db.Where("owner = ? AND key = ?", rec.Owner, rec.Key).First(&stored).Error; err != nil {
+		return model.IdempotencyKey{}, false, err
+	}
+	return stored, false, nil
+}
+
+// Complete stores the response of a reserved key for replay.
+func (r *IdempotencyRepo) Complete(ctx context.Context, owner, key string, status int, contentType string, body []byte) error {
+	return r.db.WithContext(ctx).Model(&model.IdempotencyKey{}).
+		Where("owner = ? AND key = ?", owner, key).
+		Updates(map[string]any{
+			"status_code":  status,
+			"content_type": contentType,
+			"response":     body,
+		}).Error
+}
+
+// Release drops a reserved key whose request failed, so a retry runs it
+// again instead of replaying the failure.
+func (r *IdempotencyRepo) Release(ctx context.Context, owner, key string) error {
+	return r.db.WithContext(ctx).
+		Where("owner = ? AND key = ? AND status_code IS NULL", owner, key).
+		Delete(&model.IdempotencyKey{}).Error
+}
+
+// DeleteExpired removes keys past their TTL.
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context) error {
+	res := r.db.WithContext(ctx).Where("expires_at <= NOW()").Delete(&model.IdempotencyKey{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		r.logger.Info("deleted %d expired idempotency keys", res.RowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+package repo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const listColumns = "lists.*, (SELECT COUNT(*) FROM list_items WHERE list_items.list_id = lists.id) AS item_count"
+
+type ListRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewListRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *ListRepo {
+	return &ListRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Create adds a list and gives it a random shareable slug derived from the
+// name. The slug does not change when the list is renamed.
+func (r *ListRepo) Create(ctx context.Context, list model.List) (model.List, error) {
+	slug, err := newListSlug(list.Name)
+	if err != nil {
+		return model.List{}, err
+	}
+	list.Slug = slug
+	if list.Visibility == "" {
+		list.Visibility = model.ListVisibilityPrivate
+	}
+
+	if err := r.db.WithContext(ctx).Create(&list).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return model.List{}, model.ErrAlreadyExists
+		}
+		return model.List{}, err
+	}
+	return list, nil
+}
+
+// GetDefault returns the user's watchlist, creating it on first use.
+func (r *ListRepo) GetDefault(ctx context.Context, userID int) (model.List, error) {
+	list, err := r.findDefault(ctx, userID)
+	if !errors.Is(err, model.ErrNotFound) {
+		return list, err
+	}
+
+	slug, err := newListSlug(model.DefaultListName)
+	if err != nil {
+		return model.List{}, err
+	}
+	// Concurrent first requests race on lists_user_default_idx; the loser
+	// inserts nothing and reads the winner's row.
+	err = r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&model.List{
+		UserID:     userID,
+		Name:       model.DefaultListName,
+		Slug:       slug,
+		Visibility: model.ListVisibilityPrivate,
+		IsDefault:  true,
+	}).Error
+	if err != nil {
+		return model.List{}, err
+	}
+	return r.findDefault(ctx, userID)
+}
+
+func (r *ListRepo) findDefault(ctx context.Context, userID int) (model.List, error) {
+	var list model.List
+	err := r.db.WithContext(ctx).Select(listColumns).
+		Where("user_id = ? AND is_default", userID).
+		First(&list).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.List{}, model.ErrNotFound
+		}
+		return model.List{}, err
+	}
+	return list, nil
+}
+
+// GetByUser returns the user's lists, watchlist first.
+func (r *ListRepo) GetByUser(ctx context.Context, userID int) ([]model.List, error) {
+	if _, err := r.GetDefault(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var lists []model.List
+	err := r.db.WithContext(ctx).Select(listColumns).
+		Where("user_id = ?", userID).
+		Order("is_default DESC, created_at, id").
+		Find(&lists).Error
+	if err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// Get looks a list up by ID, or by slug when no ID is given.
+func (r *ListRepo) Get(ctx context.Context, req model.Id) (model.List, error) {
+	var list model.List
+
+	query := r.db.WithContext(ctx).Select(listColumns)
+	if req.ID != 0 {
+		query = query.Where("id = ?", req.ID)
+	} else {
+		query = query.Where("slug = ?", req.Slug)
+	}
+
+	if err := query.First(&list).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.List{}, model.ErrNotFound
+		}
+		return model.List{}, err
+	}
+	return list, nil
+}
+
+// GetItems returns the movies on a list in order.
+func (r *ListRepo) GetItems(ctx context.Context, listID int) ([]model.ListEntry, error) {
+	var entries []model.ListEntry
+	err := r.db.WithContext(ctx).Table("list_items").
+		Select("list_items.position, list_items.added_at, movies.id AS movie_id, movies.title AS movie_title, movies.year AS movie_year").
+		Joins("JOIN movies ON movies.id = list_items.movie_id").
+		Where("list_items.list_id = ?", listID).
+		Order("list_items.position, list_items.added_at").
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *ListRepo) Update(ctx context.Context, list model.List) (model.List, error) {
+	updates := map[string]any{
+		"name":       list.Name,
+		"updated_at": gorm.Expr("NOW()"),
+	}
+	if list.Visibility != "" {
+		updates["visibility"] = list.Visibility
+	}
+
+	res := r.db.WithContext(ctx).Model(&model.List{}).
+		Where("id = ?", list.ID).
+		Updates(updates)
+	if res.Error != nil {
+		return model.List{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.List{}, model.ErrNotFound
+	}
+	return r.Get(ctx, model.Id{ID: list.ID})
+}
+
+// Delete removes a custom list and its items. The watchlist cannot be
+// deleted.
+func (r *ListRepo) Delete(ctx context.Context, id int) error {
+	res := r.db.WithContext(ctx).Where("NOT is_default").Delete(&model.List{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		var count int64
+		if err := r.db.WithContext(ctx).Model(&model.List{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return model.ErrDefaultList
+		}
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+// AddItem inserts a movie at a 1-based position, shifting later items down.
+// Position 0 or past the end appends.
+func (r *ListRepo) AddItem(ctx context.Context, listID, movieID, position int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		last, err := lockList(tx, listID)
+		if err != nil {
+			return err
+		}
+
+		if position <= 0 || position > last {
+			position = last + 1
+		} else if err := tx.Model(&model.ListItem{}).
+			Where("list_id = ? AND position >= ?", listID, position).
+			Update("position", gorm.Expr("position + 1")).Error; err != nil {
+			return err
+		}
+
+		err = tx.Create(&model.ListItem{ListID: listID, MovieID: movieID, Position: position}).Error
+		switch {
+		case errors.Is(err, gorm.ErrDuplicatedKey):
+			return model.ErrAlreadyExists
+		case errors.Is(err, gorm.ErrForeignKeyViolated):
+			return model.ErrNotFound
+		}
+		return err
+	})
+}
+
+// RemoveItem deletes a movie from the list and closes the gap it leaves.
+func (r *ListRepo) RemoveItem(ctx context.Context, listID, movieID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockList(tx, listID); err != nil {
+			return err
+		}
+
+		var removed model.ListItem
+		res := tx.Clauses(clause.Returning{}).
+			Where("list_id = ? AND movie_id = ?", listID, movieID).
+			Delete(&removed)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return model.ErrNotFound
+		}
+
+		return tx.Model(&model.ListItem{}).
+			Where("list_id = ? AND position > ?", listID, removed.Position).
+			Update("position", gorm.Expr("position - 1")).Error
+	})
+}
+
+// Reorder sets the item order to movieIDs, which must name every movie on
+// the list exactly once.
+func (r *ListRepo) Reorder(ctx context.Context, listID int, movieIDs []int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := lockList(tx, listID); err != nil {
+			return err
+		}
+
+		var current []int
+		if err := tx.Model(&model.ListItem{}).Where("list_id = ?", listID).Pluck("movie_id", &current).Error; err != nil {
+			return err
+		}
+
+		requested := slices.Clone(movieIDs)
+		slices.Sort(current)
+		slices.Sort(requested)
+		if !slices.Equal(current, requested) {
+			return model.ErrInvalidOrder
+		}
+
+		return tx.Exec(`
+			UPDATE list_items SET position = ordered.position
+			FROM unnest(?::INT[]) WITH ORDINALITY AS ordered(movie_id, position)
+			WHERE list_items.list_id = ? AND list_items.movie_id = ordered.movie_id`,
+			pgArray(movieIDs), listID).Error
+	})
+}
+
+// lockList serializes item changes on a list and returns the last position
+// in use.
+func lockList(tx *gorm.DB, listID int) (int, error) {
+	var list model.List
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		First(&list, listID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, model.ErrNotFound
+		}
+		return 0, err
+	}
+
+	var last int
+	if err := tx.Model(&model.ListItem{}).
+		Where("list_id = ?", listID).
+		Select("COALESCE(MAX(position), 0)").
+		Scan(&last).Error; err != nil {
+		return 0, err
+	}
+	return last, nil
+}
+
+// newListSlug builds "<name>-<random hex>", e.g. "horror-night-3f9a2c1e".
+func newListSlug(name string) (string, error) {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 40 {
+			break
+		}
+	}
+	base := strings.TrimSuffix(b.String(), "-")
+	if base == "" {
+		base = "list"
+	}
+
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base + "-" + hex.EncodeToString(buf), nil
+}
@@ -7,4 +7,15 @@ import (
 var Module = fx.Options(
 	fx.Provide(NewMovieRepo),
 	fx.Provide(NewActorRepo),
+	fx.Provide(NewGenreRepo),
+	fx.Provide(NewCreditRepo),
+	fx.Provide(NewReviewRepo),
+	fx.Provide(NewRankingRepo),
+	fx.Provide(NewSearchRepo),
+	fx.Provide(NewListRepo),
+	fx.Provide(NewWatchProgressRepo),
+	fx.Provide(NewUserRepo),
+	fx.Provide(NewRefreshTokenRepo),
+	fx.Provide(NewAPIKeyRepo),
+	fx.Provide(NewIdempotencyRepo),
 )
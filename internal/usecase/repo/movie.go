@@ -2,16 +2,49 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/movie-app/internal/config"
 	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
 	"github.com/movie-app/pkg/logger"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+// movieFields lists what clients may filter, sort and bulk update movies by.
+var movieFields = querybuilder.Schema{
+	"id":             {Column: "movies.id", Type: querybuilder.Int, Sortable: true},
+	"title":          {Column: "movies.title", Type: querybuilder.String, Sortable: true, Updatable: true},
+	"year":           {Column: "movies.year", Type: querybuilder.Int, Sortable: true, Updatable: true},
+	"plot":           {Column: "movies.plot", Type: querybuilder.String, Ops: []querybuilder.Operator{querybuilder.Search, querybuilder.IsNull}, Updatable: true, Nullable: true},
+	"average_rating": {Column: "movies.average_rating", Type: querybuilder.Float, Sortable: true},
+	"rating_count":   {Column: "movies.rating_count", Type: querybuilder.Int, Sortable: true},
+	"created_at":     {Column: "movies.created_at", Type: querybuilder.Time, Sortable: true},
+	"updated_at":     {Column: "movies.updated_at", Type: querybuilder.Time, Sortable: true},
+	"genre": {
+		Type:   querybuilder.String,
+		Ops:    []querybuilder.Operator{querybuilder.Eq, querybuilder.In},
+		Filter: filterByGenre,
+	},
+	"director": {
+		Type:   querybuilder.String,
+		Ops:    []querybuilder.Operator{querybuilder.Eq, querybuilder.Search},
+		Filter: filterByDirector,
+	},
+	"actor_id": {
+		Type:   querybuilder.Int,
+		Ops:    []querybuilder.Operator{querybuilder.Eq, querybuilder.In, querybuilder.All},
+		Filter: filterByActor,
+	},
+}
+
 type MovieRepo struct {
 	db     *gorm.DB
 	logger *logger.Logger
@@ -29,7 +62,7 @@ func NewMovieRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *Movie
 func (r *MovieRepo) Create(ctx context.Context, req model.Movie) (model.Movie, error) {
 	tx := r.db.WithContext(ctx).Begin()
 
-	if err := tx.Create(&req).Error; err != nil {
+	if err := tx.Omit(clause.Associations).Create(&req).Error; err != nil {
 		tx.Rollback()
 		r.logger.Error(fmt.Sprintf("failed to create movie: %v", err))
 		return model.Movie{}, err
@@ -45,12 +78,17 @@ func (r *MovieRepo) Create(ctx context.Context, req model.Movie) (model.Movie, e
 		var existing model.Actor
 		if err := tx.First(&existing, actor.ID).Error; err != nil {
 			tx.Rollback()
-			return model.Movie{}, fmt.Errorf("actor with ID %d not found", actor.ID)
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.Movie{}, fmt.Errorf("%w: actor %d", model.ErrUnknownReference, actor.ID)
+			}
+			return model.Movie{}, fmt.Errorf("failed to validate actor ID %d: %w", actor.ID, err)
 		}
 
 		movieActor := model.MovieActor{
-			MovieID: req.ID,
-			ActorID: actor.ID,
+			MovieID:       req.ID,
+			ActorID:       actor.ID,
+			CharacterName: actor.CharacterName,
+			BillingOrder:  actor.BillingOrder,
 		}
 
 		if err := tx.Table("movie_actors").
@@ -61,12 +99,208 @@ func (r *MovieRepo) Create(ctx context.Context, req model.Movie) (model.Movie, e
 		}
 	}
 
+	if err := linkGenres(tx, req.ID, req.Genres); err != nil {
+		tx.Rollback()
+		return model.Movie{}, err
+	}
+
+	if err := linkCrew(tx, req.ID, req.Crew); err != nil {
+		tx.Rollback()
+		return model.Movie{}, err
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return model.Movie{}, err
 	}
 
 	return req, nil
 }
+
+// actorColumns lists the actor fields embedded in cast and crew rows. It is
+// explicit so joins do not also fetch the search_vector column.
+const actorColumns = "actors.id, actors.first_name, actors.last_name, actors.role, actors.version, actors.created_at, actors.updated_at"
+
+// loadMovieDetails attaches cast, genres and crew to movies with one query
+// each, however many movies there are.
+func loadMovieDetails(db *gorm.DB, movies []model.Movie) error {
+	if err := loadCast(db, movies); err != nil {
+		return err
+	}
+	if err := loadGenres(db, movies); err != nil {
+		return err
+	}
+	return loadCrew(db, movies)
+}
+
+// loadCast attaches the credited cast, in billing order, to movies with a
+// single query.
+func loadCast(db *gorm.DB, movies []model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(movies))
+	for i := range movies {
+		ids[i] = movies[i].ID
+	}
+
+	var rows []struct {
+		MovieID int
+		model.CastMember
+	}
+	if err := db.Table("actors").
+		Select("movie_actors.movie_id, "+actorColumns+", COALESCE(movie_actors.character_name, '') AS character_name, movie_actors.billing_order").
+		Joins("JOIN movie_actors ON movie_actors.actor_id = actors.id").
+		Where("movie_actors.movie_id IN ?", ids).
+		Order("movie_actors.movie_id, movie_actors.billing_order, actors.id").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	byMovie := make(map[int][]model.CastMember, len(movies))
+	for _, row := range rows {
+		byMovie[row.MovieID] = append(byMovie[row.MovieID], row.CastMember)
+	}
+	for i := range movies {
+		movies[i].Cast = byMovie[movies[i].ID]
+		if movies[i].Cast == nil {
+			movies[i].Cast = []model.CastMember{}
+		}
+	}
+	return nil
+}
+
+// linkGenres validates that every genre exists and links it to the movie.
+func linkGenres(tx *gorm.DB, movieID int, genres []model.Genre) error {
+	seen := make(map[int]struct{})
+	for _, genre := range genres {
+		if _, ok := seen[genre.ID]; ok {
+			continue
+		}
+		seen[genre.ID] = struct{}{}
+
+		var exists int64
+		if err := tx.Model(&model.Genre{}).
+			Where("id = ?", genre.ID).
+			Count(&exists).Error; err != nil {
+			return fmt.Errorf("failed to validate genre ID %d: %w", genre.ID, err)
+		}
+		if exists == 0 {
+			return fmt.Errorf("%w: genre %d", model.ErrUnknownReference, genre.ID)
+		}
+
+		link := model.MovieGenre{MovieID: movieID, GenreID: genre.ID}
+		if err := tx.Create(&link).Error; err != nil {
+			return fmt.Errorf("failed to link genre ID %d to movie ID %d: %w", genre.ID, movieID, err)
+		}
+	}
+	return nil
+}
+
+// linkCrew validates that every person exists and adds their crew credits.
+func linkCrew(tx *gorm.DB, movieID int, crew []model.CrewMember) error {
+	for _, member := range crew {
+		var exists int64
+		if err := tx.Model(&model.Actor{}).
+			Where("id = ?", member.ID).
+			Count(&exists).Error; err != nil {
+			return fmt.Errorf("failed to validate person ID %d: %w", member.ID, err)
+		}
+		if exists == 0 {
+			return fmt.Errorf("%w: person %d", model.ErrUnknownReference, member.ID)
+		}
+
+		job := member.Job
+		if job == "" {
+			job = model.DefaultJobs[member.Department]
+		}
+
+		credit := model.Credit{
+			MovieID:    movieID,
+			PersonID:   member.ID,
+			Department: member.Department,
+			Job:        job,
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&credit).Error; err != nil {
+			return fmt.Errorf("failed to credit person ID %d on movie ID %d: %w", member.ID, movieID, err)
+		}
+	}
+	return nil
+}
+
+// loadCrew attaches crew credits to movies with a single query.
+func loadCrew(db *gorm.DB, movies []model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(movies))
+	for i := range movies {
+		ids[i] = movies[i].ID
+	}
+
+	var rows []struct {
+		MovieID int
+		model.CrewMember
+	}
+	if err := db.Table("credits").
+		Select("credits.movie_id, "+actorColumns+", credits.department, credits.job").
+		Joins("JOIN actors ON actors.id = credits.person_id").
+		Where("credits.movie_id IN ?", ids).
+		Order("credits.department, credits.id").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	byMovie := make(map[int][]model.CrewMember, len(movies))
+	for _, row := range rows {
+		byMovie[row.MovieID] = append(byMovie[row.MovieID], row.CrewMember)
+	}
+	for i := range movies {
+		movies[i].Crew = byMovie[movies[i].ID]
+		if movies[i].Crew == nil {
+			movies[i].Crew = []model.CrewMember{}
+		}
+	}
+	return nil
+}
+
+// loadGenres attaches genres to movies with a single query.
+func loadGenres(db *gorm.DB, movies []model.Movie) error {
+	if len(movies) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(movies))
+	for i := range movies {
+		ids[i] = movies[i].ID
+	}
+
+	var rows []struct {
+		MovieID int
+		model.Genre
+	}
+	if err := db.Table("genres").
+		Select("movie_genres.movie_id, genres.*").
+		Joins("JOIN movie_genres ON movie_genres.genre_id = genres.id").
+		Where("movie_genres.movie_id IN ?", ids).
+		Order("genres.name").
+		Scan(&rows).Error; err != nil {
+		return err
+	}
+
+	byMovie := make(map[int][]model.Genre, len(movies))
+	for _, row := range rows {
+		byMovie[row.MovieID] = append(byMovie[row.MovieID], row.Genre)
+	}
+	for i := range movies {
+		movies[i].Genres = byMovie[movies[i].ID]
+		if movies[i].Genres == nil {
+			movies[i].Genres = []model.Genre{}
+		}
+	}
+	return nil
+}
 func (r *MovieRepo) GetSingle(ctx context.Context, req model.Id) (model.Movie, error) {
 	var movie model.Movie
 
@@ -75,53 +309,75 @@ func (r *MovieRepo) GetSingle(ctx context.Context, req model.Id) (model.Movie, e
 		return model.Movie{}, err
 	}
 
-	var cast []model.Actor
-	if err := r.db.WithContext(ctx).
-		Table("actors").
-		Select("actors.*").
-		Joins("JOIN movie_actors ON movie_actors.actor_id = actors.id").
-		Where("movie_actors.movie_id = ?", movie.ID).
-		Find(&cast).Error; err != nil {
+	movies := []model.Movie{movie}
+	if err := loadMovieDetails(r.db.WithContext(ctx), movies); err != nil {
 		return model.Movie{}, err
 	}
-
-	movie.Cast = cast
-	return movie, nil
+	return movies[0], nil
 }
 
+// UpdateField sets the given fields on every movie matching the filters.
+// The matched rows are locked and counted first so that a filter matching
+// more than BulkUpdateMaxRows movies changes nothing. A dry run reports the
+// same IDs without writing.
 func (r *MovieRepo) UpdateField(ctx context.Context, req model.UpdateFieldRequest) (model.RowsEffected, error) {
-	db := r.db.WithContext(ctx).Model(&model.Movie{})
-
-	// Apply filters
-	for _, f := range req.Filter {
-		switch f.Type {
-		case "eq":
-			db = db.Where(f.Column+" = ?", f.Value)
-		case "ne":
-			db = db.Where(f.Column+" <> ?", f.Value)
-		case "gt":
-			db = db.Where(f.Column+" > ?", f.Value)
-		case "lt":
-			db = db.Where(f.Column+" < ?", f.Value)
-		case "gte":
-			db = db.Where(f.Column+" >= ?", f.Value)
-		case "lte":
-			db = db.Where(f.Column+" <= ?", f.Value)
-		case "search":
-			db = db.Where(f.Column+" ILIKE ?", "%"+f.Value+"%")
-		}
+	if len(req.Filter) == 0 {
+		return model.RowsEffected{}, fmt.Errorf("%w: at least one filter is required", model.ErrInvalidFilter)
+	}
+	if len(req.Items) == 0 {
+		return model.RowsEffected{}, fmt.Errorf("%w: at least one item is required", model.ErrInvalidFilter)
 	}
 
 	// Build update map
 	updateMap := map[string]interface{}{}
 	for _, item := range req.Items {
-		updateMap[item.Column] = item.Value
+		column, value, err := movieFields.Updatable(item.Column, item.Value)
+		if err != nil {
+			return model.RowsEffected{}, err
+		}
+		if _, ok := updateMap[column]; ok {
+			return model.RowsEffected{}, fmt.Errorf("%w: field %q is set more than once", model.ErrInvalidFilter, item.Column)
+		}
+		updateMap[column] = value
+	}
+	updateMap["updated_at"] = gorm.Expr("NOW()")
+	updateMap["version"] = nextVersion
+
+	res := model.RowsEffected{IDs: []int{}, DryRun: req.DryRun}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		query, err := movieFields.Filters(tx.Model(&model.Movie{}), req.Filter)
+		if err != nil {
+			return err
+		}
+		if !req.DryRun {
+			query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+		}
+
+		limit := r.cfg.BulkUpdateMaxRows
+		if err := query.Order("movies.id").Limit(limit+1).Pluck("movies.id", &res.IDs).Error; err != nil {
+			return err
+		}
+		if len(res.IDs) > limit {
+			res.IDs = nil
+			return fmt.Errorf("%w: the filter matches more than %d movies", model.ErrTooManyRows, limit)
+		}
+		if req.DryRun || len(res.IDs) == 0 {
+			res.RowsEffected = len(res.IDs)
+			return nil
+		}
+
+		// Execute update
+		result := tx.Model(&model.Movie{}).Where("id IN ?", res.IDs).Updates(updateMap)
+		res.RowsEffected = int(result.RowsAffected)
+		return result.Error
+	})
+	if err != nil {
+		return model.RowsEffected{}, err
 	}
 
-	// Execute update
-	tx := db.Updates(updateMap)
-	return model.RowsEffected{RowsEffected: int(tx.RowsAffected)}, tx.Error
+	return res, nil
 }
+
 func (r *MovieRepo) Update(ctx context.Context, req model.Movie) (model.Movie, error) {
 	tx := r.db.WithContext(ctx).Begin()
 
@@ -131,13 +387,18 @@ func (r *MovieRepo) Update(ctx context.Context, req model.Movie) (model.Movie, e
 		}
 	}()
 
+	if err := lockVersion(tx, &model.Movie{}, req.ID, req.Version); err != nil {
+		tx.Rollback()
+		return model.Movie{}, err
+	}
+
 	if err := tx.Model(&model.Movie{}).
 		Where("id = ?", req.ID).
 		Updates(map[string]any{
 			"title":      req.Title,
-			"director":   req.Director,
 			"year":       req.Year,
 			"plot":       req.Plot,
+			"version":    nextVersion,
 			"updated_at": gorm.Expr("NOW()"),
 		}).Error; err != nil {
 		tx.Rollback()
@@ -168,24 +429,56 @@ func (r *MovieRepo) Update(ctx context.Context, req model.Movie) (model.Movie, e
 		}
 		if exists == 0 {
 			tx.Rollback()
-			return model.Movie{}, fmt.Errorf("actor with ID %d not found", actor.ID)
+			return model.Movie{}, fmt.Errorf("%w: actor %d", model.ErrUnknownReference, actor.ID)
 		}
 
 		// Create movie-actor relation
-		link := model.MovieActor{MovieID: req.ID, ActorID: actor.ID}
+		link := model.MovieActor{
+			MovieID:       req.ID,
+			ActorID:       actor.ID,
+			CharacterName: actor.CharacterName,
+			BillingOrder:  actor.BillingOrder,
+		}
 		if err := tx.Create(&link).Error; err != nil {
 			tx.Rollback()
 			return model.Movie{}, fmt.Errorf("failed to link actor ID %d: %w", actor.ID, err)
 		}
 	}
 
-	// fetch updated movie with preloaded cast
+	if err := tx.Where("movie_id = ?", req.ID).Delete(&model.MovieGenre{}).Error; err != nil {
+		tx.Rollback()
+		return model.Movie{}, fmt.Errorf("failed to clear old genres: %w", err)
+	}
+
+	if err := linkGenres(tx, req.ID, req.Genres); err != nil {
+		tx.Rollback()
+		return model.Movie{}, err
+	}
+
+	if err := tx.Where("movie_id = ?", req.ID).Delete(&model.Credit{}).Error; err != nil {
+		tx.Rollback()
+		return model.Movie{}, fmt.Errorf("failed to clear old crew: %w", err)
+	}
+
+	if err := linkCrew(tx, req.ID, req.Crew); err != nil {
+		tx.Rollback()
+		return model.Movie{}, err
+	}
+
+	// fetch updated movie with cast, genres and crew
 	var updated model.Movie
-	if err := tx.Preload("Cast").First(&updated, req.ID).Error; err != nil {
+	if err := tx.First(&updated, req.ID).Error; err != nil {
 		tx.Rollback()
 		return model.Movie{}, fmt.Errorf("failed to fetch updated movie: %w", err)
 	}
 
+	movies := []model.Movie{updated}
+	if err := loadMovieDetails(tx, movies); err != nil {
+		tx.Rollback()
+		return model.Movie{}, fmt.Errorf("failed to fetch updated movie details: %w", err)
+	}
+	updated = movies[0]
+
 	if err := tx.Commit().Error; err != nil {
 		return model.Movie{}, fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -193,69 +486,346 @@ func (r *MovieRepo) Update(ctx context.Context, req model.Movie) (model.Movie, e
 	return updated, nil
 }
 
+// Patch applies patch to the current document of a movie and writes only
+// what changed. A non-zero version must match the movie's. The movie row
+// stays locked from the read to the write, so concurrent patches apply one
+// after the other. Cast members are added, changed and removed individually;
+// crew and genres are rewritten when they differ.
+func (r *MovieRepo) Patch(ctx context.Context, id, version int, patch func(model.MovieDocument) (model.MovieDocument, error)) (model.Movie, error) {
+	var updated model.Movie
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockVersion(tx, &model.Movie{}, id, version); err != nil {
+			return err
+		}
+		var movie model.Movie
+		if err := tx.First(&movie, id).Error; err != nil {
+			return err
+		}
+		movies := []model.Movie{movie}
+		if err := loadMovieDetails(tx, movies); err != nil {
+			return err
+		}
+
+		current := movieDocument(movies[0])
+		next, err := patch(movieDocument(movies[0]))
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		fields := map[string]any{}
+		if next.Title != current.Title {
+			fields["title"] = next.Title
+		}
+		if next.Year != current.Year {
+			fields["year"] = next.Year
+		}
+		if next.Plot != current.Plot {
+			fields["plot"] = next.Plot
+		}
+		if len(fields) > 0 {
+			changed = true
+			if err := tx.Model(&model.Movie{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+				return fmt.Errorf("failed to update movie fields: %w", err)
+			}
+		}
+
+		castChanged, err := patchCast(tx, id, current.Cast, next.Cast)
+		if err != nil {
+			return err
+		}
+		changed = changed || castChanged
+
+		if !reflect.DeepEqual(current.Crew, next.Crew) {
+			changed = true
+			crew := make([]model.CrewMember, 0, len(next.Crew))
+			ids := make([]int, 0, len(next.Crew))
+			for _, c := range next.Crew {
+				crew = append(crew, model.CrewMember{Actor: model.Actor{ID: c.Id}, Department: c.Department, Job: c.Job})
+				ids = append(ids, c.Id)
+			}
+			if err := requireExisting(tx, &model.Actor{}, ids, "person"); err != nil {
+				return err
+			}
+			if err := tx.Where("movie_id = ?", id).Delete(&model.Credit{}).Error; err != nil {
+				return fmt.Errorf("failed to clear old crew: %w", err)
+			}
+			if err := linkCrew(tx, id, crew); err != nil {
+				return err
+			}
+		}
+
+		if !slices.Equal(current.Genres, next.Genres) {
+			changed = true
+			genres := make([]model.Genre, 0, len(next.Genres))
+			for _, g := range next.Genres {
+				genres = append(genres, model.Genre{ID: g})
+			}
+			if err := requireExisting(tx, &model.Genre{}, next.Genres, "genre"); err != nil {
+				return err
+			}
+			if err := tx.Where("movie_id = ?", id).Delete(&model.MovieGenre{}).Error; err != nil {
+				return fmt.Errorf("failed to clear old genres: %w", err)
+			}
+			if err := linkGenres(tx, id, genres); err != nil {
+				return err
+			}
+		}
+
+		if changed {
+			if err := tx.Model(&model.Movie{}).Where("id = ?", id).Updates(map[string]any{
+				"version":    nextVersion,
+				"updated_at": gorm.Expr("NOW()"),
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.First(&updated, id).Error; err != nil {
+			return err
+		}
+		movies = []model.Movie{updated}
+		if err := loadMovieDetails(tx, movies); err != nil {
+			return err
+		}
+		updated = movies[0]
+		return nil
+	})
+	if err != nil {
+		return model.Movie{}, err
+	}
+
+	return updated, nil
+}
+
+// movieDocument converts a movie with its details loaded to the document
+// PATCH requests edit.
+func movieDocument(m model.Movie) model.MovieDocument {
+	doc := model.MovieDocument{
+		Title:  m.Title,
+		Year:   m.Year,
+		Plot:   m.Plot,
+		Cast:   make(map[int]model.CastEntry, len(m.Cast)),
+		Crew:   make([]model.CrewRequest, 0, len(m.Crew)),
+		Genres: make([]int, 0, len(m.Genres)),
+	}
+	for _, c := range m.Cast {
+		doc.Cast[c.ID] = model.CastEntry{CharacterName: c.CharacterName, BillingOrder: c.BillingOrder}
+	}
+	for _, c := range m.Crew {
+		doc.Crew = append(doc.Crew, model.CrewRequest{Id: c.ID, Department: c.Department, Job: c.Job})
+	}
+	for _, g := range m.Genres {
+		doc.Genres = append(doc.Genres, g.ID)
+	}
+	return doc
+}
+
+// patchCast brings the movie's cast from current to next, touching only the
+// members that differ. New members without a billing order are billed after
+// everyone else, in actor ID order.
+func patchCast(tx *gorm.DB, movieID int, current, next map[int]model.CastEntry) (bool, error) {
+	var removed, added []int
+	lastBilled := 0
+	for actorID, entry := range current {
+		if _, ok := next[actorID]; !ok {
+			removed = append(removed, actorID)
+		}
+		lastBilled = max(lastBilled, entry.BillingOrder)
+	}
+	for actorID, entry := range next {
+		if _, ok := current[actorID]; !ok {
+			added = append(added, actorID)
+		}
+		lastBilled = max(lastBilled, entry.BillingOrder)
+	}
+	slices.Sort(added)
+
+	changed := len(removed) > 0 || len(added) > 0
+
+	if len(removed) > 0 {
+		if err := tx.Where("movie_id = ? AND actor_id IN ?", movieID, removed).Delete(&model.MovieActor{}).Error; err != nil {
+			return false, fmt.Errorf("failed to remove cast members: %w", err)
+		}
+	}
+
+	if err := requireExisting(tx, &model.Actor{}, added, "actor"); err != nil {
+		return false, err
+	}
+	for _, actorID := range added {
+		entry := next[actorID]
+		if entry.BillingOrder == 0 {
+			lastBilled++
+			entry.BillingOrder = lastBilled
+		}
+		link := model.MovieActor{
+			MovieID:       movieID,
+			ActorID:       actorID,
+			CharacterName: entry.CharacterName,
+			BillingOrder:  entry.BillingOrder,
+		}
+		if err := tx.Create(&link).Error; err != nil {
+			return false, fmt.Errorf("failed to link actor ID %d: %w", actorID, err)
+		}
+	}
+
+	for actorID, entry := range next {
+		old, ok := current[actorID]
+		if !ok || old == entry {
+			continue
+		}
+		changed = true
+		if err := tx.Model(&model.MovieActor{}).
+			Where("movie_id = ? AND actor_id = ?", movieID, actorID).
+			Updates(map[string]any{
+				"character_name": entry.CharacterName,
+				"billing_order":  entry.BillingOrder,
+			}).Error; err != nil {
+			return false, fmt.Errorf("failed to update cast member %d: %w", actorID, err)
+		}
+	}
+
+	return changed, nil
+}
+
+// requireExisting fails with model.ErrInvalidPatch unless every ID names a
+// row of the given model's table.
+func requireExisting(tx *gorm.DB, table any, ids []int, what string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	var found []int
+	if err := tx.Model(table).Where("id IN ?", ids).Pluck("id", &found).Error; err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if !slices.Contains(found, id) {
+			return fmt.Errorf("%w: %s %d does not exist", model.ErrInvalidPatch, what, id)
+		}
+	}
+	return nil
+}
+
 func (r *MovieRepo) GetList(ctx context.Context, req model.GetListFilter) (model.MovieList, error) {
 	var movies []model.Movie
 	query := r.db.WithContext(ctx).Model(&model.Movie{})
 
-	// Filters
-	for _, f := range req.Filters {
-		switch f.Type {
-		case "eq":
-			query = query.Where(f.Column+" = ?", f.Value)
-		case "search":
-			query = query.Where(f.Column+" ILIKE ?", "%"+f.Value+"%")
-		case "gt":
-			query = query.Where(f.Column+" > ?", f.Value)
-		case "lt":
-			query = query.Where(f.Column+" < ?", f.Value)
-		case "gte":
-			query = query.Where(f.Column+" >= ?", f.Value)
-		case "lte":
-			query = query.Where(f.Column+" <= ?", f.Value)
-		}
+	query, err := movieFields.Filters(query, req.Filters)
+	if err != nil {
+		return model.MovieList{}, err
 	}
 
-	// Ordering
-	for _, o := range req.OrderBy {
-		query = query.Order(fmt.Sprintf("%s %s", o.Column, o.Order))
+	var list model.MovieList
+	if list.Facets, err = movieFacets(query, req.Facets); err != nil {
+		return model.MovieList{}, err
 	}
 
 	// Count
-	var total int64
-	if err := query.Count(&total).Error; err != nil {
-		return model.MovieList{}, err
+	if !req.SkipCount {
+		var total int64
+		if err := query.Count(&total).Error; err != nil {
+			return model.MovieList{}, err
+		}
+		list.Total = &total
 	}
 
 	// Pagination
-	offset := (req.Page - 1) * req.Limit
-	if offset < 0 {
-		offset = 0
+	query, page, err := movieFields.Paginate(query, req)
+	if err != nil {
+		return model.MovieList{}, err
 	}
 
-	if err := query.Limit(req.Limit).Offset(offset).Find(&movies).Error; err != nil {
+	if err := query.Find(&movies).Error; err != nil {
 		return model.MovieList{}, err
 	}
 
-	// Attach cast manually
-	for i := range movies {
-		var cast []model.Actor
-		err := r.db.Table("actors").
-			Select("actors.*").
-			Joins("JOIN movie_actors ON movie_actors.actor_id = actors.id").
-			Where("movie_actors.movie_id = ?", movies[i].ID).
-			Find(&cast).Error
-		if err != nil {
-			return model.MovieList{}, err
+	movies, list.NextCursor, list.PrevCursor, err = querybuilder.Cursors(movieFields, r.db.WithContext(ctx), movies, page)
+	if err != nil {
+		return model.MovieList{}, err
+	}
+
+	if err := loadMovieDetails(r.db.WithContext(ctx), movies); err != nil {
+		return model.MovieList{}, err
+	}
+
+	list.Movies = movies
+	return list, nil
+}
+
+// filterByGenre keeps movies tagged with any of the genres, given by ID or
+// by name.
+func filterByGenre(query *gorm.DB, _ querybuilder.Operator, genres []string) *gorm.DB {
+	var (
+		ids   []int
+		names []string
+	)
+	for _, g := range genres {
+		if id, err := strconv.Atoi(g); err == nil {
+			ids = append(ids, id)
+		} else {
+			names = append(names, strings.ToLower(g))
 		}
-		movies[i].Cast = cast
 	}
 
-	return model.MovieList{
-		Movies: movies,
-		Count:  int(total),
-	}, nil
+	sub := query.Session(&gorm.Session{NewDB: true}).
+		Table("movie_genres").
+		Select("1").
+		Joins("JOIN genres ON genres.id = movie_genres.genre_id").
+		Where("movie_genres.movie_id = movies.id")
+	switch {
+	case len(ids) > 0 && len(names) > 0:
+		sub = sub.Where("genres.id IN ? OR LOWER(genres.name) IN ?", ids, names)
+	case len(ids) > 0:
+		sub = sub.Where("genres.id IN ?", ids)
+	default:
+		sub = sub.Where("LOWER(genres.name) IN ?", names)
+	}
+	return query.Where("EXISTS (?)", sub)
+}
+
+// filterByActor keeps movies whose cast includes any of the actors, or all
+// of them for the all operator.
+func filterByActor(query *gorm.DB, op querybuilder.Operator, values []string) *gorm.DB {
+	ids := make([]int, 0, len(values))
+	seen := make(map[int]struct{}, len(values))
+	for _, v := range values {
+		id, _ := strconv.Atoi(v) // validated by the schema
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	if op == querybuilder.All {
+		return query.Where(`(
+			SELECT COUNT(DISTINCT movie_actors.actor_id) FROM movie_actors
+			WHERE movie_actors.movie_id = movies.id AND movie_actors.actor_id IN ?) = ?`,
+			ids, len(ids))
+	}
+	return query.Where(`EXISTS (
+		SELECT 1 FROM movie_actors
+		WHERE movie_actors.movie_id = movies.id AND movie_actors.actor_id IN ?)`, ids)
+}
+
+// filterByDirector keeps movies with a directing credit whose name matches,
+// exactly for eq and as a substring for search.
+func filterByDirector(query *gorm.DB, op querybuilder.Operator, names []string) *gorm.DB {
+	pattern := querybuilder.EscapeLike(names[0])
+	if op == querybuilder.Search {
+		pattern = "%" + pattern + "%"
+	}
+	return query.Where(`EXISTS (
+		SELECT 1 FROM credits
+		JOIN actors ON actors.id = credits.person_id
+		WHERE credits.movie_id = movies.id
+		  AND credits.department = ?
+		  AND (actors.first_name || ' ' || actors.last_name) ILIKE ? ESCAPE '\')`,
+		model.DepartmentDirecting, pattern)
 }
-func (r *MovieRepo) Delete(ctx context.Context, req model.Id) error {
+
+// Delete removes a movie with its relations. A non-zero version must match
+// the movie's.
+func (r *MovieRepo) Delete(ctx context.Context, req model.Id, version int) error {
 	tx := r.db.WithContext(ctx).Begin()
 
 	defer func() {
@@ -264,12 +834,35 @@ func (r *MovieRepo) Delete(ctx context.Context, req model.Id) error {
 		}
 	}()
 
+	if err := lockVersion(tx, &model.Movie{}, req.ID, version); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	if err := tx.Where("movie_id = ?", req.ID).Delete(&model.MovieActor{}).Error; err != nil {
 		tx.Rollback()
 		r.logger.Error("failed to delete movie_actors relations", zap.Int("movie_id", req.ID), zap.Error(err))
 		return fmt.Errorf("failed to delete movie_actors relations for movie ID %d: %w", req.ID, err)
 	}
 
+	if err := tx.Where("movie_id = ?", req.ID).Delete(&model.MovieGenre{}).Error; err != nil {
+		tx.Rollback()
+		r.logger.Error("failed to delete movie_genres relations", zap.Int("movie_id", req.ID), zap.Error(err))
+		return fmt.Errorf("failed to delete movie_genres relations for movie ID %d: %w", req.ID, err)
+	}
+
+	if err := tx.Where("movie_id = ?", req.ID).Delete(&model.Credit{}).Error; err != nil {
+		tx.Rollback()
+		r.logger.Error("failed to delete credits", zap.Int("movie_id", req.ID), zap.Error(err))
+		return fmt.Errorf("failed to delete credits for movie ID %d: %w", req.ID, err)
+	}
+
+	if err := tx.Where("movie_id = ?", req.ID).Delete(&model.ListItem{}).Error; err != nil {
+		tx.Rollback()
+		r.logger.Error("failed to delete list items", zap.Int("movie_id", req.ID), zap.Error(err))
+		return fmt.Errorf("failed to delete list items for movie ID %d: %w", req.ID, err)
+	}
+
 	if err := tx.Delete(&model.Movie{}, req.ID).Error; err != nil {
 		tx.Rollback()
 		r.logger.Error("failed to delete movie", zap.Int("movie_id", req.ID), zap.Error(err))
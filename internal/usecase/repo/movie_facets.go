@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/movie-app/internal/model"
+	"gorm.io/gorm"
+)
+
+// facetBucketLimit caps the genre and director buckets to the most common
+// values; decades are few enough to list them all.
+const facetBucketLimit = 20
+
+var movieFacetQueries = map[string]func(db, ids *gorm.DB) ([]model.FacetBucket, error){
+	model.FacetGenre:    genreFacet,
+	model.FacetDecade:   decadeFacet,
+	model.FacetDirector: directorFacet,
+}
+
+// movieFacets counts the movies matched by query per value of each named
+// facet. query must carry the list filters but no ordering or paging.
+func movieFacets(query *gorm.DB, names []string) (map[string][]model.FacetBucket, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	for _, name := range names {
+		if _, ok := movieFacetQueries[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown facet %q, expected one of %s, %s, %s",
+				model.ErrInvalidFilter, name, model.FacetGenre, model.FacetDecade, model.FacetDirector)
+		}
+	}
+
+	ids := query.Session(&gorm.Session{}).Select("movies.id")
+	newDB := query.Session(&gorm.Session{NewDB: true})
+
+	facets := make(map[string][]model.FacetBucket, len(names))
+	for _, name := range names {
+		if _, ok := facets[name]; ok {
+			continue
+		}
+		buckets, err := movieFacetQueries[name](newDB, ids)
+		if err != nil {
+			return nil, err
+		}
+		facets[name] = buckets
+	}
+
+	return facets, nil
+}
+
+func genreFacet(db, ids *gorm.DB) ([]model.FacetBucket, error) {
+	buckets := []model.FacetBucket{}
+	err := db.Table("movie_genres").
+		Select("genres.id::TEXT AS value, genres.name AS label, COUNT(*) AS count").
+		Joins("JOIN genres ON genres.id = movie_genres.genre_id").
+		Where("movie_genres.movie_id IN (?)", ids).
+		Group("genres.id, genres.name").
+		Order("count DESC, label").
+		Limit(facetBucketLimit).
+		Scan(&buckets).Error
+	return buckets, err
+}
+
+func decadeFacet(db, ids *gorm.DB) ([]model.FacetBucket, error) {
+	var rows []struct {
+		Decade int
+		Count  int64
+	}
+	if err := db.Table("movies").
+		Select("year / 10 * 10 AS decade, COUNT(*) AS count").
+		Where("movies.id IN (?)", ids).
+		Group("decade").
+		Order("decade DESC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	buckets := make([]model.FacetBucket, 0, len(rows))
+	for _, row := range rows {
+		buckets = append(buckets, model.FacetBucket{
+			Value: strconv.Itoa(row.Decade) + "," + strconv.Itoa(row.Decade+9),
+			Label: strconv.Itoa(row.Decade) + "s",
+			Count: row.Count,
+		})
+	}
+	return buckets, nil
+}
+
+func directorFacet(db, ids *gorm.DB) ([]model.FacetBucket, error) {
+	buckets := []model.FacetBucket{}
+	err := db.Table("credits").
+		Select("actors.first_name || ' ' || actors.last_name AS value, "+
+			"actors.first_name || ' ' || actors.last_name AS label, "+
+			"COUNT(DISTINCT credits.movie_id) AS count").
+		Joins("JOIN actors ON actors.id = credits.person_id").
+		Where("credits.department = ?", model.DepartmentDirecting).
+		Where("credits.movie_id IN (?)", ids).
+		Group("actors.id, actors.first_name, actors.last_name").
+		Order("count DESC, label").
+		Limit(facetBucketLimit).
+		Scan(&buckets).Error
+	return buckets, err
+}
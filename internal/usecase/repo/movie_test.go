@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/movie-app/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// emptyDriver answers every query with no rows, which is enough to run the
+// repository code without a database.
+type emptyDriver struct{}
+
+func (emptyDriver) Open(string) (driver.Conn, error) { return emptyConn{}, nil }
+
+type emptyConn struct{}
+
+func (emptyConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (emptyConn) Close() error                        { return nil }
+func (emptyConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (emptyConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return emptyRows{}, nil
+}
+
+type emptyRows struct{}
+
+func (emptyRows) Columns() []string         { return nil }
+func (emptyRows) Close() error              { return nil }
+func (emptyRows) Next([]driver.Value) error { return io.EOF }
+
+func init() {
+	sql.Register("movie-app-empty", emptyDriver{})
+}
+
+// countingDB opens a gorm DB on emptyDriver and returns it with a pointer to
+// the number of queries it has run.
+func countingDB(tb testing.TB) (*gorm.DB, *int) {
+	tb.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DriverName: "movie-app-empty"}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	queries := new(int)
+	count := func(*gorm.DB) { *queries++ }
+	if err := db.Callback().Query().After("gorm:query").Register("test:count", count); err != nil {
+		tb.Fatal(err)
+	}
+	if err := db.Callback().Row().After("gorm:row").Register("test:count", count); err != nil {
+		tb.Fatal(err)
+	}
+	return db, queries
+}
+
+func moviePage(n int) []model.Movie {
+	movies := make([]model.Movie, n)
+	for i := range movies {
+		movies[i].ID = i + 1
+	}
+	return movies
+}
+
+// BenchmarkLoadMovieDetails checks that attaching cast, genres and crew costs
+// the same number of queries whatever the page size.
+func BenchmarkLoadMovieDetails(b *testing.B) {
+	db, queries := countingDB(b)
+
+	perPage := make(map[int]int)
+	for _, size := range []int{10, 100} {
+		*queries = 0
+		if err := loadMovieDetails(db, moviePage(size)); err != nil {
+			b.Fatal(err)
+		}
+		perPage[size] = *queries
+	}
+	if perPage[10] != perPage[100] {
+		b.Fatalf("a page of 10 movies ran %d queries, a page of 100 ran %d", perPage[10], perPage[100])
+	}
+
+	for _, size := range []int{10, 100} {
+		movies := moviePage(size)
+		b.Run(fmt.Sprintf("page=%d", size), func(b *testing.B) {
+			*queries = 0
+			for i := 0; i < b.N; i++ {
+				if err := loadMovieDetails(db, movies); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(*queries)/float64(b.N), "queries/op")
+		})
+	}
+}
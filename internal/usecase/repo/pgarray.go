@@ -0,0 +1,14 @@
+package repo
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+// pgArray passes s as a single Postgres array parameter, encoded by pgx.
+// gorm expands plain slices into "(a,b,c)" lists, which unnest and ANY do
+// not accept.
+func pgArray[T any](s []T) pgtype.Array[T] {
+	return pgtype.Array[T]{
+		Elements: s,
+		Dims:     []pgtype.ArrayDimension{{Length: int32(len(s)), LowerBound: 1}},
+		Valid:    true,
+	}
+}
@@ -0,0 +1,105 @@
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type RankingRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewRankingRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *RankingRepo {
+	return &RankingRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Refresh rebuilds the movie_rankings snapshot without blocking readers.
+func (r *RankingRepo) Refresh(ctx context.Context) error {
+	return r.db.WithContext(ctx).Exec("REFRESH MATERIALIZED VIEW CONCURRENTLY movie_rankings").Error
+}
+
+// GetTop ranks movies by the IMDb weighted rating
+//
+//	WR = v/(v+m) * R + m/(v+m) * C
+//
+// where v is the movie's vote count, R its mean rating, C the mean over all
+// votes and m the minimum number of votes needed to be listed.
+func (r *RankingRepo) GetTop(ctx context.Context, req model.TopRatedFilter) (model.TopRatedList, error) {
+	var (
+		list  model.TopRatedList
+		stats struct {
+			MeanRating  float64
+			RefreshedAt *time.Time
+		}
+	)
+
+	if err := r.db.WithContext(ctx).Table("movie_rankings").
+		Select("mean_rating, refreshed_at").
+		Limit(1).
+		Scan(&stats).Error; err != nil {
+		return model.TopRatedList{}, err
+	}
+
+	m := req.MinVotes
+	query := r.db.WithContext(ctx).Table("movie_rankings").
+		Joins("JOIN movies ON movies.id = movie_rankings.movie_id").
+		Where("movie_rankings.rating_count >= ?", m)
+
+	if req.Genre != "" {
+		query = filterByGenre(query, querybuilder.Eq, []string{req.Genre})
+	}
+	if req.YearFrom != 0 {
+		query = query.Where("movies.year >= ?", req.YearFrom)
+	}
+	if req.YearTo != 0 {
+		query = query.Where("movies.year <= ?", req.YearTo)
+	}
+
+	if err := query.Count(&list.Total).Error; err != nil {
+		return model.TopRatedList{}, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if offset < 0 {
+		offset = 0
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	err := query.Select(`movies.id, movies.title, movies.year,
+			ROUND(movie_rankings.rating_sum::NUMERIC / movie_rankings.rating_count, 2) AS average_rating,
+			movie_rankings.rating_count,
+			ROUND(
+				movie_rankings.rating_count::NUMERIC / (movie_rankings.rating_count + ?) * movie_rankings.rating_sum / movie_rankings.rating_count
+				+ ?::NUMERIC / (movie_rankings.rating_count + ?) * movie_rankings.mean_rating,
+			3) AS weighted_rating`, m, m, m).
+		Order("weighted_rating DESC, movie_rankings.rating_count DESC, movies.id").
+		Offset(offset).
+		Limit(req.Limit).
+		Scan(&list.Movies).Error
+	if err != nil {
+		return model.TopRatedList{}, err
+	}
+
+	for i := range list.Movies {
+		list.Movies[i].Rank = offset + i + 1
+	}
+
+	list.MinVotes = m
+	list.MeanRating = stats.MeanRating
+	list.RefreshedAt = stats.RefreshedAt
+	return list, nil
+}
@@ -0,0 +1,115 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefreshTokenRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewRefreshTokenRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *RefreshTokenRepo {
+	return &RefreshTokenRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (r *RefreshTokenRepo) Create(ctx context.Context, token model.RefreshToken) (model.RefreshToken, error) {
+	if err := r.db.WithContext(ctx).Create(&token).Error; err != nil {
+		return model.RefreshToken{}, err
+	}
+	return token, nil
+}
+
+// Rotate exchanges the refresh token identified by tokenHash for next, which
+// inherits the user and family of the old one. Presenting a token that was
+// already rotated or revoked is treated as theft: the whole family is revoked
+// and ErrTokenReused is returned.
+func (r *RefreshTokenRepo) Rotate(ctx context.Context, tokenHash string, next model.RefreshToken) (model.RefreshToken, error) {
+	var reused bool
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.RefreshToken
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", tokenHash).
+			First(&current).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return model.ErrNotFound
+			}
+			return err
+		}
+
+		if current.RevokedAt != nil {
+			reused = true
+			return revokeFamily(tx, current.FamilyID)
+		}
+		if time.Now().After(current.ExpiresAt) {
+			return model.ErrTokenExpired
+		}
+
+		next.UserID = current.UserID
+		next.FamilyID = current.FamilyID
+		if err := tx.Create(&next).Error; err != nil {
+			return fmt.Errorf("failed to store rotated token: %w", err)
+		}
+
+		return tx.Model(&model.RefreshToken{}).
+			Where("id = ?", current.ID).
+			Updates(map[string]any{
+				"revoked_at":  gorm.Expr("NOW()"),
+				"replaced_by": next.ID,
+			}).Error
+	})
+	if err != nil {
+		return model.RefreshToken{}, err
+	}
+	if reused {
+		r.logger.Warn("refresh token reuse detected, family revoked")
+		return model.RefreshToken{}, model.ErrTokenReused
+	}
+
+	return next, nil
+}
+
+// RevokeByHash revokes the family of the refresh token identified by tokenHash.
+func (r *RefreshTokenRepo) RevokeByHash(ctx context.Context, tokenHash string) error {
+	var token model.RefreshToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.ErrNotFound
+		}
+		return err
+	}
+	return revokeFamily(r.db.WithContext(ctx), token.FamilyID)
+}
+
+// IsFamilyActive reports whether the family still has an unrevoked, unexpired
+// head token. Access tokens issued for a dead family must be rejected.
+func (r *RefreshTokenRepo) IsFamilyActive(ctx context.Context, familyID string) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL AND expires_at > NOW()", familyID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func revokeFamily(tx *gorm.DB, familyID string) error {
+	return tx.Model(&model.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", gorm.Expr("NOW()")).Error
+}
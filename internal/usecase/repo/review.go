@@ -0,0 +1,104 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReviewRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewReviewRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *ReviewRepo {
+	return &ReviewRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Create adds a user's review. The movie's rating aggregates are updated by
+// a trigger in the same transaction.
+func (r *ReviewRepo) Create(ctx context.Context, review model.Review) (model.Review, error) {
+	if err := r.db.WithContext(ctx).Create(&review).Error; err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrDuplicatedKey):
+			return model.Review{}, model.ErrAlreadyExists
+		case errors.Is(err, gorm.ErrForeignKeyViolated):
+			return model.Review{}, model.ErrNotFound
+		}
+		return model.Review{}, err
+	}
+	return review, nil
+}
+
+// Update edits the review a user left on a movie.
+func (r *ReviewRepo) Update(ctx context.Context, review model.Review) (model.Review, error) {
+	var updated model.Review
+	res := r.db.WithContext(ctx).Model(&updated).
+		Clauses(clause.Returning{}).
+		Where("movie_id = ? AND user_id = ?", review.MovieID, review.UserID).
+		Updates(map[string]any{
+			"rating":     review.Rating,
+			"body":       review.Body,
+			"updated_at": gorm.Expr("NOW()"),
+		})
+	if res.Error != nil {
+		return model.Review{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.Review{}, model.ErrNotFound
+	}
+	return updated, nil
+}
+
+func (r *ReviewRepo) Delete(ctx context.Context, movieID, userID int) error {
+	res := r.db.WithContext(ctx).
+		Where("movie_id = ? AND user_id = ?", movieID, userID).
+		Delete(&model.Review{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ReviewRepo) GetList(ctx context.Context, movieID int, req model.GetListFilter) (model.ReviewList, error) {
+	var (
+		reviews []model.Review
+		total   int64
+	)
+
+	tx := r.db.WithContext(ctx).Model(&model.Review{}).Where("movie_id = ?", movieID)
+
+	if err := tx.Count(&total).Error; err != nil {
+		return model.ReviewList{}, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if offset < 0 {
+		offset = 0
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	if err := tx.Order("created_at desc, id desc").Offset(offset).Limit(req.Limit).Find(&reviews).Error; err != nil {
+		return model.ReviewList{}, err
+	}
+
+	return model.ReviewList{
+		Reviews: reviews,
+		Total:   total,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package repo
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/internal/querybuilder"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// searchQuery parses the user's input twice: stemmed English matches titles
+// and plots, simple matches people's names as they were indexed.
+const searchQuery = "SELECT websearch_to_tsquery('english', ?) || websearch_to_tsquery('simple', ?) AS query"
+
+const (
+	movieHits = "SELECT 'movie' AS type, m.id, m.title AS name, m.year, COALESCE(m.plot, '') AS body, " +
+		"ts_rank(m.search_vector, q.query) AS rank " +
+		"FROM movies m, q WHERE m.search_vector @@ q.query"
+	actorHits = "SELECT 'actor' AS type, a.id, a.first_name || ' ' || a.last_name AS name, NULL::INT AS year, '' AS body, " +
+		"ts_rank(a.search_vector, q.query) AS rank " +
+		"FROM actors a, q WHERE a.search_vector @@ q.query"
+
+	headlineConfig  = "CASE hits.type WHEN 'movie' THEN 'english'::regconfig ELSE 'simple'::regconfig END"
+	highlightOpts   = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	snippetOpts     = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=\" ... \""
+	searchHitsOrder = "rank DESC, type, id"
+)
+
+// Autocomplete compares accent-folded, lower-cased text. The expressions
+// match the index definitions so the planner can use them.
+const (
+	movieLabel = "lower(immutable_unaccent(title))"
+	actorLabel = "lower(immutable_unaccent(first_name || ' ' || last_name))"
+
+	// minFuzzyLength is the shortest input matched by similarity; shorter
+	// inputs have no useful trigrams and are matched by prefix only.
+	minFuzzyLength = 3
+)
+
+type SearchRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewSearchRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *SearchRepo {
+	return &SearchRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+// Search ranks movies and actors against the search vectors maintained by
+// the database triggers and highlights the matches of the returned page only,
+// since ts_headline has to re-parse the text.
+func (r *SearchRepo) Search(ctx context.Context, req model.SearchRequest) (model.SearchResults, error) {
+	res := model.SearchResults{Query: req.Query, Results: []model.SearchResult{}}
+
+	var parts []string
+	if len(req.Types) == 0 || slices.Contains(req.Types, model.SearchTypeMovie) {
+		parts = append(parts, movieHits)
+	}
+	if len(req.Types) == 0 || slices.Contains(req.Types, model.SearchTypeActor) {
+		parts = append(parts, actorHits)
+	}
+	if len(parts) == 0 {
+		return res, nil
+	}
+	hits := strings.Join(parts, " UNION ALL ")
+
+	db := r.db.WithContext(ctx)
+	if err := db.Raw("WITH q AS ("+searchQuery+") SELECT COUNT(*) FROM ("+hits+") hits",
+		req.Query, req.Query).Scan(&res.Total).Error; err != nil {
+		return model.SearchResults{}, err
+	}
+	if res.Total == 0 {
+		return res, nil
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if offset < 0 {
+		offset = 0
+	}
+
+	err := db.Raw("WITH q AS ("+searchQuery+"), "+
+		"hits AS ("+hits+" ORDER BY "+searchHitsOrder+" LIMIT ? OFFSET ?) "+
+		"SELECT hits.type, hits.id, hits.name, hits.year, hits.rank, "+
+		"ts_headline("+headlineConfig+", hits.name, q.query, '"+highlightOpts+"') AS highlight, "+
+		"ts_headline("+headlineConfig+", hits.body, q.query, '"+snippetOpts+"') AS snippet "+
+		"FROM hits, q ORDER BY "+searchHitsOrder,
+		req.Query, req.Query, req.Limit, offset).Scan(&res.Results).Error
+	if err != nil {
+		return model.SearchResults{}, err
+	}
+
+	return res, nil
+}
+
+// Autocomplete suggests movie titles and actor names that start with the
+// input, or contain a word similar to it. Prefix matches rank first, then
+// closer and shorter matches.
+func (r *SearchRepo) Autocomplete(ctx context.Context, req model.AutocompleteRequest) (model.Suggestions, error) {
+	res := model.Suggestions{Suggestions: []model.Suggestion{}}
+
+	fuzzy := len([]rune(req.Query)) >= minFuzzyLength
+	var parts []string
+	if len(req.Types) == 0 || slices.Contains(req.Types, model.SearchTypeMovie) {
+		parts = append(parts, suggestionsSQL(model.SearchTypeMovie, "movies", "title", "year", movieLabel, fuzzy))
+	}
+	if len(req.Types) == 0 || slices.Contains(req.Types, model.SearchTypeActor) {
+		parts = append(parts, suggestionsSQL(model.SearchTypeActor, "actors", "first_name || ' ' || last_name", "NULL::INT", actorLabel, fuzzy))
+	}
+	if len(parts) == 0 {
+		return res, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.AutocompleteTimeout)
+	defer cancel()
+
+	err := r.db.WithContext(ctx).Raw("SELECT type, id, label, year FROM ("+strings.Join(parts, " UNION ALL ")+") s "+
+		"ORDER BY prefix DESC, score DESC, length(label), type, id LIMIT @limit",
+		map[string]any{
+			"term":   req.Query,
+			"prefix": querybuilder.EscapeLike(req.Query) + "%",
+			"limit":  req.Limit,
+		}).Scan(&res.Suggestions).Error
+	if err != nil {
+		return model.Suggestions{}, err
+	}
+
+	return res, nil
+}
+
+// suggestionsSQL selects up to @limit candidates from one table. The search
+// terms are folded by the same immutable expression as the column, so it is
+// evaluated once at plan time and the indexes apply.
+func suggestionsSQL(kind, table, label, year, folded string, fuzzy bool) string {
+	prefix := folded + " LIKE lower(immutable_unaccent(@prefix))"
+	where := prefix
+	if fuzzy {
+		where += " OR lower(immutable_unaccent(@term)) <% " + folded
+	}
+	return "(SELECT '" + kind + "' AS type, id, " + label + " AS label, " + year + " AS year, " +
+		prefix + " AS prefix, " +
+		"word_similarity(lower(immutable_unaccent(@term)), " + folded + ") AS score " +
+		"FROM " + table + " WHERE " + where + " " +
+		"ORDER BY prefix DESC, score DESC, length(" + label + "), id LIMIT @limit)"
+}
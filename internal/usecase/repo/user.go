@@ -0,0 +1,73 @@
+package repo
+
+import (
+	"context"
+	"errors"
+
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type UserRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+}
+
+func NewUserRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *UserRepo {
+	return &UserRepo{
+		db:     db,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (r *UserRepo) Create(ctx context.Context, user model.User) (model.User, error) {
+	if err := r.db.WithContext(ctx).Create(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return model.User{}, model.ErrEmailTaken
+		}
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+func (r *UserRepo) GetByEmail(ctx context.Context, email string) (model.User, error) {
+	var user model.User
+	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.User{}, model.ErrNotFound
+		}
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+func (r *UserRepo) GetByID(ctx context.Context, id int) (model.User, error) {
+	var user model.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.User{}, model.ErrNotFound
+		}
+		return model.User{}, err
+	}
+	return user, nil
+}
+
+func (r *UserRepo) UpdateRole(ctx context.Context, id int, role string) (model.User, error) {
+	res := r.db.WithContext(ctx).Model(&model.User{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"role":       role,
+			"updated_at": gorm.Expr("NOW()"),
+		})
+	if res.Error != nil {
+		return model.User{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.User{}, model.ErrNotFound
+	}
+	return r.GetByID(ctx, id)
+}
@@ -0,0 +1,30 @@
+package repo
+
+import (
+	"github.com/movie-app/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// lockVersion locks the row with the given ID in table until the end of tx.
+// It fails with model.ErrNotFound when there is no such row, and with
+// model.ErrVersionMismatch when version is set and differs from the row's.
+func lockVersion(tx *gorm.DB, table any, id, version int) error {
+	var versions []int
+	if err := tx.Model(table).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		Pluck("version", &versions).Error; err != nil {
+		return err
+	}
+	if len(versions) == 0 {
+		return model.ErrNotFound
+	}
+	if version != 0 && versions[0] != version {
+		return model.ErrVersionMismatch
+	}
+	return nil
+}
+
+// nextVersion is the SET expression bumping a row's version.
+var nextVersion = gorm.Expr("version + 1")
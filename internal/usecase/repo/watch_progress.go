@@ -0,0 +1,241 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/movie-app/internal/config"
+	"github.com/movie-app/internal/model"
+	"github.com/movie-app/pkg/logger"
+	"gorm.io/gorm"
+)
+
+type watchKey struct {
+	userID  int
+	movieID int
+}
+
+// WatchProgressRepo stores playback progress. Players report progress every
+// few seconds, so reports are coalesced in memory per user and movie and
+// written as one batch upsert on Flush. Buffered reports only live in this
+// process until then; with cfg.ProgressWriteThrough every report is upserted
+// directly instead, which the updated_at guard keeps safe under concurrent
+// and out-of-order writes.
+type WatchProgressRepo struct {
+	db     *gorm.DB
+	logger *logger.Logger
+	cfg    *config.Config
+
+	mu      sync.Mutex
+	pending map[watchKey]model.WatchProgress
+	// flushMu keeps a size-triggered flush and the scheduled one from
+	// writing at the same time.
+	flushMu sync.Mutex
+}
+
+func NewWatchProgressRepo(db *gorm.DB, cfg *config.Config, logger *logger.Logger) *WatchProgressRepo {
+	return &WatchProgressRepo{
+		db:      db,
+		cfg:     cfg,
+		logger:  logger,
+		pending: make(map[watchKey]model.WatchProgress),
+	}
+}
+
+// Record buffers a progress report, replacing any older pending report for
+// the same movie. The buffer is flushed early once it grows past
+// cfg.ProgressBufferSize.
+func (r *WatchProgressRepo) Record(ctx context.Context, p model.WatchProgress) error {
+	if r.cfg.ProgressWriteThrough {
+		return r.upsert(ctx, []model.WatchProgress{p})
+	}
+
+	r.mu.Lock()
+	r.merge(p)
+	full := r.cfg.ProgressBufferSize > 0 && len(r.pending) >= r.cfg.ProgressBufferSize
+	r.mu.Unlock()
+
+	if full {
+		return r.Flush(ctx)
+	}
+	return nil
+}
+
+// merge must be called with r.mu held.
+func (r *WatchProgressRepo) merge(p model.WatchProgress) {
+	key := watchKey{userID: p.UserID, movieID: p.MovieID}
+	if cur, ok := r.pending[key]; ok && cur.ReportedAt.After(p.ReportedAt) {
+		return
+	}
+	r.pending[key] = p
+}
+
+// Flush writes all pending reports. A report never overwrites a newer one
+// already stored, and reports for movies or users deleted in the meantime
+// are dropped. If the database rejects the batch, the reports are written
+// one by one so a single bad report cannot hold back the others; reports it
+// rejects on their own are dropped. Reports that fail for any other reason,
+// such as a lost connection, are put back for the next flush.
+func (r *WatchProgressRepo) Flush(ctx context.Context) error {
+	r.flushMu.Lock()
+	defer r.flushMu.Unlock()
+
+	r.mu.Lock()
+	batch := make([]model.WatchProgress, 0, len(r.pending))
+	for _, p := range r.pending {
+		batch = append(batch, p)
+	}
+	r.pending = make(map[watchKey]model.WatchProgress, len(batch))
+	r.mu.Unlock()
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	err := r.upsert(ctx, batch)
+	if err == nil {
+		return nil
+	}
+	if !rejected(err) {
+		r.requeue(batch)
+		return err
+	}
+
+	r.logger.Warn("watch progress batch rejected, retrying reports one by one: %v", err)
+	var (
+		retry   []model.WatchProgress
+		lastErr error
+	)
+	for _, p := range batch {
+		switch err := r.upsert(ctx, []model.WatchProgress{p}); {
+		case err == nil:
+		case rejected(err):
+			r.logger.Error("dropping watch progress of user %d on movie %d: %v", p.UserID, p.MovieID, err)
+		default:
+			retry = append(retry, p)
+			lastErr = err
+		}
+	}
+	if len(retry) > 0 {
+		r.requeue(retry)
+		return fmt.Errorf("failed to write %d watch progress reports: %w", len(retry), lastErr)
+	}
+	return nil
+}
+
+func (r *WatchProgressRepo) requeue(reports []model.WatchProgress) {
+	r.mu.Lock()
+	for _, p := range reports {
+		r.merge(p)
+	}
+	r.mu.Unlock()
+}
+
+// rejected reports whether err is Postgres refusing the statement itself,
+// which retrying the same data will not fix. The database is opened with
+// TranslateError, so constraint violations arrive as gorm errors.
+func rejected(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) ||
+		errors.Is(err, gorm.ErrCheckConstraintViolated) ||
+		errors.Is(err, gorm.ErrForeignKeyViolated) ||
+		errors.Is(err, gorm.ErrDuplicatedKey)
+}
+
+func (r *WatchProgressRepo) upsert(ctx context.Context, batch []model.WatchProgress) error {
+	var (
+		userIDs     = make([]int, 0, len(batch))
+		movieIDs    = make([]int, 0, len(batch))
+		positions   = make([]int, 0, len(batch))
+		durations   = make([]int, 0, len(batch))
+		completed   = make([]bool, 0, len(batch))
+		reportedAts = make([]time.Time, 0, len(batch))
+	)
+	for _, p := range batch {
+		userIDs = append(userIDs, p.UserID)
+		movieIDs = append(movieIDs, p.MovieID)
+		positions = append(positions, p.PositionSeconds)
+		durations = append(durations, p.DurationSeconds)
+		completed = append(completed, p.Completed)
+		reportedAts = append(reportedAts, p.ReportedAt)
+	}
+
+	return r.db.WithContext(ctx).Exec(`
+		INSERT INTO watch_progress AS wp
+			(user_id, movie_id, position_seconds, duration_seconds, completed, started_at, updated_at, completed_at)
+		SELECT p.user_id, p.movie_id, p.position_seconds, p.duration_seconds, p.completed,
+			p.reported_at::TIMESTAMP, p.reported_at::TIMESTAMP,
+			CASE WHEN p.completed THEN p.reported_at::TIMESTAMP END
+		FROM unnest(?::INT[], ?::INT[], ?::INT[], ?::INT[], ?::BOOLEAN[], ?::TIMESTAMPTZ[])
+			AS p(user_id, movie_id, position_seconds, duration_seconds, completed, reported_at)
+		JOIN movies ON movies.id = p.movie_id
+		JOIN users ON users.id = p.user_id
+		ON CONFLICT (user_id, movie_id) DO UPDATE SET
+			position_seconds = EXCLUDED.position_seconds,
+			duration_seconds = EXCLUDED.duration_seconds,
+			completed = EXCLUDED.completed,
+			updated_at = EXCLUDED.updated_at,
+			completed_at = CASE
+				WHEN EXCLUDED.completed THEN COALESCE(wp.completed_at, EXCLUDED.completed_at)
+			END
+		WHERE wp.updated_at <= EXCLUDED.updated_at`,
+		pgArray(userIDs), pgArray(movieIDs), pgArray(positions),
+		pgArray(durations), pgArray(completed), pgArray(reportedAts),
+	).Error
+}
+
+// GetInProgress returns unfinished movies, most recently watched first.
+func (r *WatchProgressRepo) GetInProgress(ctx context.Context, userID, limit int) ([]model.WatchEntry, error) {
+	var entries []model.WatchEntry
+	err := r.entries(ctx, userID).
+		Where("NOT watch_progress.completed AND watch_progress.position_seconds > 0").
+		Order("watch_progress.updated_at DESC, watch_progress.movie_id").
+		Limit(limit).
+		Scan(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetHistory returns everything the user has watched, most recent first.
+func (r *WatchProgressRepo) GetHistory(ctx context.Context, userID int, req model.GetListFilter) (model.WatchHistory, error) {
+	var history model.WatchHistory
+
+	if err := r.db.WithContext(ctx).Table("watch_progress").
+		Where("user_id = ?", userID).
+		Count(&history.Total).Error; err != nil {
+		return model.WatchHistory{}, err
+	}
+
+	offset := (req.Page - 1) * req.Limit
+	if offset < 0 {
+		offset = 0
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	err := r.entries(ctx, userID).
+		Order("watch_progress.updated_at DESC, watch_progress.movie_id").
+		Offset(offset).
+		Limit(req.Limit).
+		Scan(&history.Entries).Error
+	if err != nil {
+		return model.WatchHistory{}, err
+	}
+	return history, nil
+}
+
+func (r *WatchProgressRepo) entries(ctx context.Context, userID int) *gorm.DB {
+	return r.db.WithContext(ctx).Table("watch_progress").
+		Select(`movies.id AS movie_id, movies.title AS movie_title, movies.year AS movie_year,
+			watch_progress.position_seconds, watch_progress.duration_seconds, watch_progress.completed,
+			watch_progress.started_at, watch_progress.updated_at, watch_progress.completed_at`).
+		Joins("JOIN movies ON movies.id = watch_progress.movie_id").
+		Where("watch_progress.user_id = ?", userID)
+}
@@ -0,0 +1,13 @@
+CREATE TABLE api_keys (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    prefix VARCHAR(16) NOT NULL,
+    key_hash CHAR(64) NOT NULL UNIQUE,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    created_by INT,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+
+    created_at TIMESTAMP DEFAULT now(),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+);
@@ -0,0 +1,2 @@
+DROP TABLE movie_genres;
+DROP TABLE genres;
\ No newline at end of file
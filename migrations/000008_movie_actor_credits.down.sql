@@ -0,0 +1,5 @@
+DROP INDEX movie_actors_movie_id_billing_order_idx;
+
+ALTER TABLE movie_actors
+    DROP COLUMN character_name,
+    DROP COLUMN billing_order;
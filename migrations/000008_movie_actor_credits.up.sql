@@ -0,0 +1,5 @@
+ALTER TABLE movie_actors
+    ADD COLUMN character_name VARCHAR(128),
+    ADD COLUMN billing_order INT NOT NULL DEFAULT 0;
+
+CREATE INDEX movie_actors_movie_id_billing_order_idx ON movie_actors (movie_id, billing_order);
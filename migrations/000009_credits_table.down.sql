@@ -0,0 +1,16 @@
+ALTER TABLE movies ADD COLUMN director TEXT NOT NULL DEFAULT '';
+
+UPDATE movies m
+SET director = d.names
+FROM (
+    SELECT c.movie_id, string_agg(btrim(a.first_name || ' ' || a.last_name), ', ' ORDER BY c.id) AS names
+    FROM credits c
+    JOIN actors a ON a.id = c.person_id
+    WHERE c.department = 'directing'
+    GROUP BY c.movie_id
+) d
+WHERE d.movie_id = m.id;
+
+ALTER TABLE movies ALTER COLUMN director DROP DEFAULT;
+
+DROP TABLE credits;
@@ -0,0 +1,43 @@
+-- People are rows of the actors table; credits attach them to movies as crew.
+CREATE TABLE credits (
+    id SERIAL PRIMARY KEY,
+    movie_id INT NOT NULL,
+    person_id INT NOT NULL,
+    department VARCHAR(32) NOT NULL
+        CHECK (department IN ('directing', 'writing', 'producing', 'music', 'cinematography')),
+    job VARCHAR(64) NOT NULL,
+
+    created_at TIMESTAMP DEFAULT now(),
+    UNIQUE (movie_id, person_id, department, job),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (person_id) REFERENCES actors(id) ON DELETE CASCADE
+);
+
+CREATE INDEX credits_person_id_idx ON credits (person_id);
+
+-- Move the free-text directors into people, reusing anyone with the same name.
+CREATE TEMPORARY TABLE director_names AS
+SELECT
+    id AS movie_id,
+    LEFT(split_part(btrim(director), ' ', 1), 32) AS first_name,
+    LEFT(btrim(substr(btrim(director), length(split_part(btrim(director), ' ', 1)) + 1)), 32) AS last_name
+FROM movies
+WHERE btrim(director) <> '';
+
+INSERT INTO actors (first_name, last_name, role)
+SELECT DISTINCT d.first_name, d.last_name, 'director'
+FROM director_names d
+WHERE NOT EXISTS (
+    SELECT 1 FROM actors a
+    WHERE a.first_name = d.first_name AND a.last_name = d.last_name
+);
+
+INSERT INTO credits (movie_id, person_id, department, job)
+SELECT d.movie_id, MIN(a.id), 'directing', 'Director'
+FROM director_names d
+JOIN actors a ON a.first_name = d.first_name AND a.last_name = d.last_name
+GROUP BY d.movie_id;
+
+DROP TABLE director_names;
+
+ALTER TABLE movies DROP COLUMN director;
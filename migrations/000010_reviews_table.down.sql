@@ -0,0 +1,7 @@
+DROP TABLE reviews;
+DROP FUNCTION movies_apply_review();
+
+ALTER TABLE movies
+    DROP COLUMN rating_count,
+    DROP COLUMN rating_sum,
+    DROP COLUMN average_rating;
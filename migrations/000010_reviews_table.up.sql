@@ -0,0 +1,56 @@
+CREATE TABLE reviews (
+    id SERIAL PRIMARY KEY,
+    movie_id INT NOT NULL,
+    user_id INT NOT NULL,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 10),
+    body TEXT,
+
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    UNIQUE (movie_id, user_id),
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX reviews_movie_id_created_at_idx ON reviews (movie_id, created_at DESC, id DESC);
+
+ALTER TABLE movies
+    ADD COLUMN rating_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN rating_sum INT NOT NULL DEFAULT 0,
+    ADD COLUMN average_rating NUMERIC(4, 2) NOT NULL DEFAULT 0;
+
+-- Aggregates are adjusted incrementally so concurrent reviews on the same
+-- movie serialize on the movie row instead of racing on a recount.
+CREATE FUNCTION movies_apply_review() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE movies SET
+            rating_count = rating_count + 1,
+            rating_sum = rating_sum + NEW.rating,
+            average_rating = ROUND((rating_sum + NEW.rating)::NUMERIC / (rating_count + 1), 2)
+        WHERE id = NEW.movie_id;
+    ELSIF TG_OP = 'UPDATE' THEN
+        UPDATE movies SET
+            rating_sum = rating_sum - OLD.rating + NEW.rating,
+            average_rating = ROUND((rating_sum - OLD.rating + NEW.rating)::NUMERIC / rating_count, 2)
+        WHERE id = NEW.movie_id;
+    ELSE
+        UPDATE movies SET
+            rating_count = rating_count - 1,
+            rating_sum = rating_sum - OLD.rating,
+            average_rating = CASE
+                WHEN rating_count - 1 = 0 THEN 0
+                ELSE ROUND((rating_sum - OLD.rating)::NUMERIC / (rating_count - 1), 2)
+            END
+        WHERE id = OLD.movie_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reviews_apply_to_movie
+AFTER INSERT OR DELETE OR UPDATE OF rating ON reviews
+FOR EACH ROW EXECUTE FUNCTION movies_apply_review();
+
+CREATE INDEX movies_average_rating_idx ON movies (average_rating DESC, id);
+CREATE INDEX movies_rating_count_idx ON movies (rating_count DESC, id);
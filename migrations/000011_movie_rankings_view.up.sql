@@ -0,0 +1,19 @@
+-- Snapshot of per-movie vote totals plus the catalogue-wide mean rating used
+-- as the prior of the weighted ranking. Refreshed periodically by the app.
+CREATE MATERIALIZED VIEW movie_rankings AS
+SELECT
+    m.id AS movie_id,
+    m.rating_count,
+    m.rating_sum,
+    stats.mean_rating,
+    now() AS refreshed_at
+FROM movies m
+CROSS JOIN (
+    SELECT COALESCE(SUM(rating_sum)::NUMERIC / NULLIF(SUM(rating_count), 0), 0) AS mean_rating
+    FROM movies
+) stats
+WHERE m.rating_count > 0;
+
+-- Required by REFRESH MATERIALIZED VIEW CONCURRENTLY.
+CREATE UNIQUE INDEX movie_rankings_movie_id_idx ON movie_rankings (movie_id);
+CREATE INDEX movie_rankings_rating_count_idx ON movie_rankings (rating_count);
@@ -0,0 +1,29 @@
+CREATE TABLE lists (
+    id SERIAL PRIMARY KEY,
+    user_id INT NOT NULL,
+    name VARCHAR(128) NOT NULL,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    visibility VARCHAR(16) NOT NULL DEFAULT 'private' CHECK (visibility IN ('private', 'public')),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMP DEFAULT now(),
+    updated_at TIMESTAMP DEFAULT now(),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX lists_user_id_idx ON lists (user_id);
+-- Each user has at most one default watchlist.
+CREATE UNIQUE INDEX lists_user_default_idx ON lists (user_id) WHERE is_default;
+
+CREATE TABLE list_items (
+    list_id INT NOT NULL,
+    movie_id INT NOT NULL,
+    position INT NOT NULL,
+    added_at TIMESTAMP DEFAULT now(),
+    PRIMARY KEY (list_id, movie_id),
+    FOREIGN KEY (list_id) REFERENCES lists(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE INDEX list_items_list_id_position_idx ON list_items (list_id, position);
+CREATE INDEX list_items_movie_id_idx ON list_items (movie_id);
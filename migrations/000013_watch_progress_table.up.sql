@@ -0,0 +1,17 @@
+CREATE TABLE watch_progress (
+    user_id INT NOT NULL,
+    movie_id INT NOT NULL,
+    position_seconds INT NOT NULL DEFAULT 0 CHECK (position_seconds >= 0),
+    duration_seconds INT NOT NULL DEFAULT 0 CHECK (duration_seconds >= 0),
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    started_at TIMESTAMP NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP NOT NULL DEFAULT now(),
+    completed_at TIMESTAMP,
+    PRIMARY KEY (user_id, movie_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (movie_id) REFERENCES movies(id) ON DELETE CASCADE
+);
+
+CREATE INDEX watch_progress_user_updated_idx ON watch_progress (user_id, updated_at DESC, movie_id);
+CREATE INDEX watch_progress_in_progress_idx ON watch_progress (user_id, updated_at DESC) WHERE NOT completed;
+CREATE INDEX watch_progress_movie_id_idx ON watch_progress (movie_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS actors_last_name_id_idx;
+DROP INDEX IF EXISTS actors_created_at_id_idx;
+DROP INDEX IF EXISTS movies_title_id_idx;
+DROP INDEX IF EXISTS movies_year_id_idx;
+DROP INDEX IF EXISTS movies_created_at_id_idx;
@@ -0,0 +1,7 @@
+-- Sort keys of the list endpoints, with id as the tie-breaker used by
+-- cursor pagination.
+CREATE INDEX IF NOT EXISTS movies_created_at_id_idx ON movies (created_at, id);
+CREATE INDEX IF NOT EXISTS movies_year_id_idx ON movies (year, id);
+CREATE INDEX IF NOT EXISTS movies_title_id_idx ON movies (title, id);
+CREATE INDEX IF NOT EXISTS actors_created_at_id_idx ON actors (created_at, id);
+CREATE INDEX IF NOT EXISTS actors_last_name_id_idx ON actors (last_name, id);
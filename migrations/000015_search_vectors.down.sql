@@ -0,0 +1,18 @@
+DROP TRIGGER IF EXISTS actors_rename_search_vector ON actors;
+DROP TRIGGER IF EXISTS actors_search_vector ON actors;
+DROP TRIGGER IF EXISTS credits_search_vector ON credits;
+DROP TRIGGER IF EXISTS movie_actors_search_vector ON movie_actors;
+DROP TRIGGER IF EXISTS movies_search_vector ON movies;
+
+DROP FUNCTION IF EXISTS actors_rename_search_vector_update();
+DROP FUNCTION IF EXISTS actors_search_vector_update();
+DROP FUNCTION IF EXISTS movie_people_search_vector_update();
+DROP FUNCTION IF EXISTS movies_search_vector_update();
+DROP FUNCTION IF EXISTS refresh_movie_search_vector(INT);
+DROP FUNCTION IF EXISTS movie_search_document(INT, TEXT, TEXT);
+
+DROP INDEX IF EXISTS actors_search_vector_idx;
+DROP INDEX IF EXISTS movies_search_vector_idx;
+
+ALTER TABLE actors DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE movies DROP COLUMN IF EXISTS search_vector;
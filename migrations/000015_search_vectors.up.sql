@@ -0,0 +1,98 @@
+ALTER TABLE movies ADD COLUMN search_vector tsvector;
+ALTER TABLE actors ADD COLUMN search_vector tsvector;
+
+-- Titles and plots are stemmed as English; people's names use the simple
+-- configuration so they are matched as written. Weights rank title over
+-- director over cast over plot.
+CREATE FUNCTION movie_search_document(p_movie_id INT, p_title TEXT, p_plot TEXT) RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('english', COALESCE(p_title, '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT string_agg(a.first_name || ' ' || a.last_name, ' ')
+            FROM credits c
+            JOIN actors a ON a.id = c.person_id
+            WHERE c.movie_id = p_movie_id AND c.department = 'directing'
+        ), '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE((
+            SELECT string_agg(a.first_name || ' ' || a.last_name, ' ')
+            FROM movie_actors ma
+            JOIN actors a ON a.id = ma.actor_id
+            WHERE ma.movie_id = p_movie_id
+        ), '')), 'C')
+        || setweight(to_tsvector('english', COALESCE(p_plot, '')), 'D')
+$$ LANGUAGE sql STABLE;
+
+CREATE FUNCTION refresh_movie_search_vector(p_movie_id INT) RETURNS void AS $$
+    UPDATE movies SET search_vector = movie_search_document(id, title, plot) WHERE id = p_movie_id;
+$$ LANGUAGE sql;
+
+CREATE FUNCTION movies_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := movie_search_document(NEW.id, NEW.title, NEW.plot);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movies_search_vector
+BEFORE INSERT OR UPDATE OF title, plot ON movies
+FOR EACH ROW EXECUTE FUNCTION movies_search_vector_update();
+
+-- Cast and crew changes re-index the movies they touch.
+CREATE FUNCTION movie_people_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM refresh_movie_search_vector(NEW.movie_id);
+    ELSIF TG_OP = 'DELETE' THEN
+        PERFORM refresh_movie_search_vector(OLD.movie_id);
+    ELSE
+        PERFORM refresh_movie_search_vector(NEW.movie_id);
+        IF OLD.movie_id <> NEW.movie_id THEN
+            PERFORM refresh_movie_search_vector(OLD.movie_id);
+        END IF;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movie_actors_search_vector
+AFTER INSERT OR UPDATE OR DELETE ON movie_actors
+FOR EACH ROW EXECUTE FUNCTION movie_people_search_vector_update();
+
+CREATE TRIGGER credits_search_vector
+AFTER INSERT OR UPDATE OR DELETE ON credits
+FOR EACH ROW EXECUTE FUNCTION movie_people_search_vector_update();
+
+CREATE FUNCTION actors_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := setweight(to_tsvector('simple', NEW.first_name || ' ' || NEW.last_name), 'A');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER actors_search_vector
+BEFORE INSERT OR UPDATE OF first_name, last_name ON actors
+FOR EACH ROW EXECUTE FUNCTION actors_search_vector_update();
+
+-- A renamed person re-indexes every movie they are credited on.
+CREATE FUNCTION actors_rename_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    UPDATE movies SET search_vector = movie_search_document(id, title, plot)
+    WHERE id IN (
+        SELECT movie_id FROM movie_actors WHERE actor_id = NEW.id
+        UNION
+        SELECT movie_id FROM credits WHERE person_id = NEW.id
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER actors_rename_search_vector
+AFTER UPDATE OF first_name, last_name ON actors
+FOR EACH ROW
+WHEN (OLD.first_name IS DISTINCT FROM NEW.first_name OR OLD.last_name IS DISTINCT FROM NEW.last_name)
+EXECUTE FUNCTION actors_rename_search_vector_update();
+
+UPDATE movies SET search_vector = movie_search_document(id, title, plot);
+UPDATE actors SET search_vector = setweight(to_tsvector('simple', first_name || ' ' || last_name), 'A');
+
+CREATE INDEX movies_search_vector_idx ON movies USING GIN (search_vector);
+CREATE INDEX actors_search_vector_idx ON actors USING GIN (search_vector);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS actors_name_prefix_idx;
+DROP INDEX IF EXISTS actors_name_trgm_idx;
+DROP INDEX IF EXISTS movies_title_prefix_idx;
+DROP INDEX IF EXISTS movies_title_trgm_idx;
+
+DROP FUNCTION IF EXISTS immutable_unaccent(TEXT);
@@ -0,0 +1,21 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent() is only STABLE because its dictionary could change, so it cannot
+-- be used in an index expression. Pinning the dictionary makes the wrapper
+-- safe to declare IMMUTABLE.
+CREATE FUNCTION immutable_unaccent(TEXT) RETURNS TEXT AS $$
+    SELECT public.unaccent('public.unaccent'::regdictionary, $1)
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+
+-- Trigram indexes serve fuzzy matching, pattern_ops indexes serve prefix
+-- matching of inputs too short to have trigrams.
+CREATE INDEX movies_title_trgm_idx ON movies
+    USING GIN (lower(immutable_unaccent(title)) gin_trgm_ops);
+CREATE INDEX movies_title_prefix_idx ON movies
+    (lower(immutable_unaccent(title)) text_pattern_ops);
+
+CREATE INDEX actors_name_trgm_idx ON actors
+    USING GIN (lower(immutable_unaccent(first_name || ' ' || last_name)) gin_trgm_ops);
+CREATE INDEX actors_name_prefix_idx ON actors
+    (lower(immutable_unaccent(first_name || ' ' || last_name)) text_pattern_ops);
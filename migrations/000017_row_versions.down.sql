@@ -0,0 +1,2 @@
+ALTER TABLE actors DROP COLUMN IF EXISTS version;
+ALTER TABLE movies DROP COLUMN IF EXISTS version;
@@ -0,0 +1,5 @@
+-- version is bumped by the application on every edit of the row's own data
+-- and backs the ETag / If-Match checks. Trigger maintained columns such as
+-- rating aggregates and search vectors do not bump it.
+ALTER TABLE movies ADD COLUMN version INT NOT NULL DEFAULT 1;
+ALTER TABLE actors ADD COLUMN version INT NOT NULL DEFAULT 1;
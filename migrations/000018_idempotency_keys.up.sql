@@ -0,0 +1,17 @@
+-- Responses to POST requests sent with an Idempotency-Key header, replayed
+-- when the same caller retries with the same key. A NULL status_code marks a
+-- request that is still being processed.
+CREATE TABLE idempotency_keys (
+    owner VARCHAR(64) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    fingerprint CHAR(64) NOT NULL,
+    status_code INT,
+    content_type VARCHAR(255),
+    response BYTEA,
+
+    created_at TIMESTAMP DEFAULT now(),
+    expires_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (owner, key)
+);
+
+CREATE INDEX idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
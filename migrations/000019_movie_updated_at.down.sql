@@ -0,0 +1,36 @@
+DROP TRIGGER IF EXISTS genres_touch_movies ON genres;
+DROP TRIGGER IF EXISTS actors_touch_movies ON actors;
+DROP TRIGGER IF EXISTS movie_genres_touch_movie ON movie_genres;
+DROP TRIGGER IF EXISTS credits_touch_movie ON credits;
+DROP TRIGGER IF EXISTS movie_actors_touch_movie ON movie_actors;
+
+DROP FUNCTION IF EXISTS genres_touch_movies();
+DROP FUNCTION IF EXISTS actors_touch_movies();
+DROP FUNCTION IF EXISTS movie_links_touch();
+
+CREATE OR REPLACE FUNCTION movies_apply_review() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE movies SET
+            rating_count = rating_count + 1,
+            rating_sum = rating_sum + NEW.rating,
+            average_rating = ROUND((rating_sum + NEW.rating)::NUMERIC / (rating_count + 1), 2)
+        WHERE id = NEW.movie_id;
+    ELSIF TG_OP = 'UPDATE' THEN
+        UPDATE movies SET
+            rating_sum = rating_sum - OLD.rating + NEW.rating,
+            average_rating = ROUND((rating_sum - OLD.rating + NEW.rating)::NUMERIC / rating_count, 2)
+        WHERE id = NEW.movie_id;
+    ELSE
+        UPDATE movies SET
+            rating_count = rating_count - 1,
+            rating_sum = rating_sum - OLD.rating,
+            average_rating = CASE
+                WHEN rating_count - 1 = 0 THEN 0
+                ELSE ROUND((rating_sum - OLD.rating)::NUMERIC / (rating_count - 1), 2)
+            END
+        WHERE id = OLD.movie_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,89 @@
+-- movies.updated_at moves whenever anything embedded in a movie's
+-- representation changes, so it can be served as Last-Modified.
+
+CREATE OR REPLACE FUNCTION movies_apply_review() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE movies SET
+            rating_count = rating_count + 1,
+            rating_sum = rating_sum + NEW.rating,
+            average_rating = ROUND((rating_sum + NEW.rating)::NUMERIC / (rating_count + 1), 2),
+            updated_at = now()
+        WHERE id = NEW.movie_id;
+    ELSIF TG_OP = 'UPDATE' THEN
+        UPDATE movies SET
+            rating_sum = rating_sum - OLD.rating + NEW.rating,
+            average_rating = ROUND((rating_sum - OLD.rating + NEW.rating)::NUMERIC / rating_count, 2),
+            updated_at = now()
+        WHERE id = NEW.movie_id;
+    ELSE
+        UPDATE movies SET
+            rating_count = rating_count - 1,
+            rating_sum = rating_sum - OLD.rating,
+            average_rating = CASE
+                WHEN rating_count - 1 = 0 THEN 0
+                ELSE ROUND((rating_sum - OLD.rating)::NUMERIC / (rating_count - 1), 2)
+            END,
+            updated_at = now()
+        WHERE id = OLD.movie_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Cast, crew and genre links, including those removed by a cascading
+-- delete of the person or genre.
+CREATE FUNCTION movie_links_touch() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE movies SET updated_at = now() WHERE id = NEW.movie_id;
+    ELSIF TG_OP = 'DELETE' THEN
+        UPDATE movies SET updated_at = now() WHERE id = OLD.movie_id;
+    ELSE
+        UPDATE movies SET updated_at = now() WHERE id IN (OLD.movie_id, NEW.movie_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movie_actors_touch_movie
+AFTER INSERT OR UPDATE OR DELETE ON movie_actors
+FOR EACH ROW EXECUTE FUNCTION movie_links_touch();
+
+CREATE TRIGGER credits_touch_movie
+AFTER INSERT OR UPDATE OR DELETE ON credits
+FOR EACH ROW EXECUTE FUNCTION movie_links_touch();
+
+CREATE TRIGGER movie_genres_touch_movie
+AFTER INSERT OR UPDATE OR DELETE ON movie_genres
+FOR EACH ROW EXECUTE FUNCTION movie_links_touch();
+
+-- Movies embed the whole actor and genre rows, so any edit to one touches
+-- every movie it appears on.
+CREATE FUNCTION actors_touch_movies() RETURNS trigger AS $$
+BEGIN
+    UPDATE movies SET updated_at = now()
+    WHERE id IN (
+        SELECT movie_id FROM movie_actors WHERE actor_id = NEW.id
+        UNION
+        SELECT movie_id FROM credits WHERE person_id = NEW.id
+    );
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER actors_touch_movies
+AFTER UPDATE ON actors
+FOR EACH ROW EXECUTE FUNCTION actors_touch_movies();
+
+CREATE FUNCTION genres_touch_movies() RETURNS trigger AS $$
+BEGIN
+    UPDATE movies SET updated_at = now()
+    WHERE id IN (SELECT movie_id FROM movie_genres WHERE genre_id = NEW.id);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER genres_touch_movies
+AFTER UPDATE ON genres
+FOR EACH ROW EXECUTE FUNCTION genres_touch_movies();
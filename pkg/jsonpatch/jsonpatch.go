@@ -0,0 +1,394 @@
+// Package jsonpatch applies RFC 7396 JSON Merge Patch and RFC 6902 JSON
+// Patch documents to JSON values.
+package jsonpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+const (
+	MergePatchType = "application/merge-patch+json"
+	JSONPatchType  = "application/json-patch+json"
+)
+
+var (
+	// ErrInvalidPatch reports a patch document that is not well formed.
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPathNotFound reports an operation on a location that does not
+	// exist in the target document.
+	ErrPathNotFound = errors.New("path not found")
+	// ErrTestFailed reports a "test" operation whose value did not match.
+	ErrTestFailed = errors.New("test operation failed")
+)
+
+// MergePatch applies an RFC 7396 merge patch to doc. Members set to null are
+// removed, objects are merged recursively and any other value, arrays
+// included, replaces the target member.
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(mergePatch(target, p))
+}
+
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergePatch(t[key], value)
+	}
+	return t
+}
+
+// Operation is one RFC 6902 operation.
+type Operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+
+	path, from []string
+	value      any
+}
+
+// Patch is an RFC 6902 JSON Patch, applied in order and as a whole.
+type Patch []Operation
+
+// Decode parses and validates a JSON Patch document.
+func Decode(b []byte) (Patch, error) {
+	var p Patch
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	for i := range p {
+		op := &p[i]
+		var err error
+		if op.path, err = parsePointer(op.Path); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			if op.Value == nil {
+				return nil, fmt.Errorf("%w: operation %d: %q requires a value", ErrInvalidPatch, i, op.Op)
+			}
+			if op.value, err = decode(op.Value); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalidPatch, i, err)
+			}
+		case "move", "copy":
+			if op.from, err = parsePointer(op.From); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: from: %v", ErrInvalidPatch, i, err)
+			}
+			if op.Op == "move" && isPrefix(op.from, op.path) && len(op.from) < len(op.path) {
+				return nil, fmt.Errorf("%w: operation %d: cannot move a value into itself", ErrInvalidPatch, i)
+			}
+		case "remove":
+		default:
+			return nil, fmt.Errorf("%w: operation %d: unknown op %q", ErrInvalidPatch, i, op.Op)
+		}
+	}
+	return p, nil
+}
+
+// Apply runs the operations against doc and returns the patched document.
+// doc itself is not modified, and nothing is returned if any operation
+// fails.
+func (p Patch) Apply(doc []byte) ([]byte, error) {
+	root, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, op := range p {
+		if root, err = op.apply(root); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+	return json.Marshal(root)
+}
+
+func (op Operation) apply(root any) (any, error) {
+	switch op.Op {
+	case "add":
+		return add(root, op.path, op.value)
+	case "remove":
+		root, _, err := remove(root, op.path)
+		return root, err
+	case "replace":
+		if _, err := get(root, op.path); err != nil {
+			return nil, err
+		}
+		if len(op.path) == 0 {
+			return op.value, nil
+		}
+		root, _, err := remove(root, op.path)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, op.path, op.value)
+	case "move":
+		root, value, err := remove(root, op.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, op.path, value)
+	case "copy":
+		value, err := get(root, op.from)
+		if err != nil {
+			return nil, err
+		}
+		return add(root, op.path, deepCopy(value))
+	case "test":
+		value, err := get(root, op.path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(value, op.value) {
+			return nil, ErrTestFailed
+		}
+		return root, nil
+	}
+	return nil, ErrInvalidPatch
+}
+
+func get(node any, path []string) (any, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[token]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+			node = child
+		case []any:
+			i, err := index(token, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, ErrPathNotFound
+		}
+	}
+	return node, nil
+}
+
+func add(root any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return update(root, path, func(parent any, key string) (any, error) {
+		switch n := parent.(type) {
+		case map[string]any:
+			n[key] = value
+			return n, nil
+		case []any:
+			if key == "-" {
+				return append(n, value), nil
+			}
+			i, err := index(key, len(n))
+			if err != nil {
+				return nil, err
+			}
+			n = append(n, nil)
+			copy(n[i+1:], n[i:])
+			n[i] = value
+			return n, nil
+		}
+		return nil, ErrPathNotFound
+	})
+}
+
+func remove(root any, path []string) (any, any, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+	var removed any
+	root, err := update(root, path, func(parent any, key string) (any, error) {
+		switch n := parent.(type) {
+		case map[string]any:
+			value, ok := n[key]
+			if !ok {
+				return nil, ErrPathNotFound
+			}
+			removed = value
+			delete(n, key)
+			return n, nil
+		case []any:
+			i, err := index(key, len(n)-1)
+			if err != nil {
+				return nil, err
+			}
+			removed = n[i]
+			return append(n[:i], n[i+1:]...), nil
+		}
+		return nil, ErrPathNotFound
+	})
+	return root, removed, err
+}
+
+// update walks to the parent of the last path token and replaces it with the
+// result of fn, writing the new value back up the tree since appending to an
+// array may reallocate it.
+func update(node any, path []string, fn func(parent any, key string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		child, ok := n[path[0]]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		child, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[path[0]] = child
+		return n, nil
+	case []any:
+		i, err := index(path[0], len(n)-1)
+		if err != nil {
+			return nil, err
+		}
+		child, err := update(n[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	}
+	return nil, ErrPathNotFound
+}
+
+// index parses an array index token no greater than max.
+func index(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, ErrPathNotFound
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > max {
+		return 0, ErrPathNotFound
+	}
+	return i, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into unescaped tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("pointer %q must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func decode(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+func deepCopy(v any) any {
+	switch n := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(n))
+		for key, value := range n {
+			m[key] = deepCopy(value)
+		}
+		return m
+	case []any:
+		s := make([]any, len(n))
+		for i, value := range n {
+			s[i] = deepCopy(value)
+		}
+		return s
+	}
+	return v
+}
+
+// equal compares JSON values as RFC 6902 "test" does: numbers by value,
+// objects regardless of member order.
+func equal(a, b any) bool {
+	switch x := a.(type) {
+	case json.Number:
+		y, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		fx, _, errX := big.ParseFloat(x.String(), 10, 256, big.ToNearestEven)
+		fy, _, errY := big.ParseFloat(y.String(), 10, 256, big.ToNearestEven)
+		return errX == nil && errY == nil && fx.Cmp(fy) == 0
+	case map[string]any:
+		y, ok := b.(map[string]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for key, value := range x {
+			other, ok := y[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		y, ok := b.([]any)
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equal(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
@@ -37,7 +37,7 @@ func ParseJWT(tokenString string, jwtKey string) (jwt.MapClaims, error) {
 
 		// Return the secret key
 		return []byte(jwtKey), nil
-	})
+	}, jwt.WithExpirationRequired(), jwt.WithIssuedAt())
 
 	if err != nil {
 		return nil, err
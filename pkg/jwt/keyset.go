@@ -0,0 +1,202 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is a single signing or verification key identified by its kid.
+// Keys loaded from a public-key PEM can only verify tokens.
+type Key struct {
+	ID        string
+	Method    jwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// KeySet signs tokens with its current key and verifies tokens against any
+// key it holds, selected by the "kid" header. Keeping retired keys in the set
+// lets tokens they issued stay valid until they expire.
+type KeySet struct {
+	current *Key
+	keys    map[string]*Key
+}
+
+// NewHMACKeySet returns a key set that signs and verifies with a shared
+// HS256 secret. Tokens carry no kid.
+func NewHMACKeySet(secret string) *KeySet {
+	key := &Key{
+		Method:    jwt.SigningMethodHS256,
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}
+	return &KeySet{
+		current: key,
+		keys:    map[string]*Key{"": key},
+	}
+}
+
+// NewKeySet builds a key set from keys and signs with the key named
+// currentKID.
+func NewKeySet(currentKID string, keys ...*Key) (*KeySet, error) {
+	ks := &KeySet{keys: make(map[string]*Key, len(keys))}
+	for _, key := range keys {
+		if _, ok := ks.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate kid %q", key.ID)
+		}
+		ks.keys[key.ID] = key
+	}
+
+	current, ok := ks.keys[currentKID]
+	if !ok {
+		return nil, fmt.Errorf("signing kid %q is not in the key set", currentKID)
+	}
+	if current.signKey == nil {
+		return nil, fmt.Errorf("signing kid %q has no private key", currentKID)
+	}
+	ks.current = current
+
+	return ks, nil
+}
+
+// LoadKeyFile reads a PEM encoded RSA or Ed25519 key. Private keys
+// (PKCS#1 or PKCS#8) can sign and verify, public keys (PKIX) only verify.
+func LoadKeyFile(kid, path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseKeyPEM(kid, data)
+}
+
+// ParseKeyPEM is LoadKeyFile for in-memory PEM data.
+func ParseKeyPEM(kid string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %q: no PEM block found", kid)
+	}
+
+	var raw interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		raw, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		raw, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		raw, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %q: unsupported PEM block %q", kid, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %q: %w", kid, err)
+	}
+
+	key := &Key{ID: kid}
+	switch k := raw.(type) {
+	case *rsa.PrivateKey:
+		key.Method, key.signKey, key.verifyKey = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.Method, key.verifyKey = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.Method, key.signKey, key.verifyKey = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.Method, key.verifyKey = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("key %q: unsupported key type %T", kid, raw)
+	}
+
+	return key, nil
+}
+
+// Sign signs claims with the current key and sets the kid header.
+func (ks *KeySet) Sign(claims map[string]interface{}) (string, error) {
+	token := jwt.NewWithClaims(ks.current.Method, jwt.MapClaims(claims))
+	if ks.current.ID != "" {
+		token.Header["kid"] = ks.current.ID
+	}
+	return token.SignedString(ks.current.signKey)
+}
+
+// Parse verifies tokenString against the key named by its kid header and
+// requires an unexpired "exp" claim. The token's alg must match the key's.
+func (ks *KeySet) Parse(tokenString string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := ks.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown kid %q", kid)
+		}
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return key.verifyKey, nil
+	}, jwt.WithExpirationRequired(), jwt.WithIssuedAt())
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is the document served at /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public halves of all asymmetric keys. Shared HMAC secrets
+// are never published.
+func (ks *KeySet) JWKS() JWKS {
+	set := JWKS{Keys: []JWK{}}
+	for _, key := range ks.keys {
+		switch pub := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "RSA",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kty: "OKP",
+				Kid: key.ID,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].Kid < set.Keys[j].Kid })
+	return set
+}